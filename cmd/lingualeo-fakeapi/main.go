@@ -0,0 +1,43 @@
+// Command lingualeo-fakeapi runs an in-memory stand-in for the Lingualeo API.
+//
+// Point the CLI at it with:
+//
+//	lingualeo --api-url http://127.0.0.1:8080 -e user@example.com -p password hello
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/trezorg/lingualeo/internal/fakeapi"
+)
+
+const readHeaderTimeout = 5 * time.Second
+
+func main() {
+	os.Exit(run())
+}
+
+func run() int {
+	addr := flag.String("addr", "127.0.0.1:8080", "Address to listen on")
+	email := flag.String("email", fakeapi.DefaultEmail, "Accepted email")
+	password := flag.String("password", fakeapi.DefaultPassword, "Accepted password")
+	flag.Parse()
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           fakeapi.NewServer(fakeapi.WithCredentials(*email, *password)),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "fake Lingualeo API listening on http://%s\n", *addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.Error("fake api server failed", "error", err)
+		return 1
+	}
+	return 0
+}
@@ -24,12 +24,12 @@ func run() int {
 	app, err := translator.Parse(version)
 	if err != nil {
 		if errors.Is(err, translator.ErrHelpOrVersionShown) {
-			return 0
+			return translator.ExitSuccess
 		}
 		if msgErr := messages.Message(messages.RED, "Error: %v\n", err); msgErr != nil {
 			_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		}
-		return 1
+		return translator.ExitError
 	}
 
 	level, err := logger.ParseLevel(app.LogLevel)
@@ -37,28 +37,35 @@ func run() int {
 		if msgErr := messages.Message(messages.RED, "Error: %v\n", err); msgErr != nil {
 			_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		}
-		return 1
+		return translator.ExitError
 	}
 	logger.Prepare(level, app.LogPrettyPrint)
 
 	if err = translator.Bootstrap(&app); err != nil {
 		slog.Error("failed to setup dependencies", "error", err)
-		return 1
+		return translator.ExitError
 	}
 
 	if err = app.Validate(); err != nil {
 		slog.Error("invalid configuration", "error", err)
-		return 1
+		return translator.ExitError
 	}
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
 	defer stop()
 
-	if err = app.Auth(ctx); err != nil {
+	if err = app.Authenticate(ctx); err != nil {
 		slog.ErrorContext(ctx, "auth error", "error", err)
-		return 1
+		return translator.ExitError
 	}
 
-	app.TranslateWithReverseRussian(ctx)
-	return 0
+	runErr := app.Run(ctx)
+	if err = app.SaveSession(); err != nil {
+		slog.WarnContext(ctx, "cannot save session", "error", err)
+	}
+	if runErr != nil {
+		slog.ErrorContext(ctx, "command failed", "error", runErr)
+		return translator.ExitError
+	}
+	return app.Report().ExitCode()
 }
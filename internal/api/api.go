@@ -10,28 +10,33 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
-	"net/http/httputil"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/trezorg/lingualeo/internal/httpclient"
+	"github.com/trezorg/lingualeo/internal/lang"
 
 	"github.com/avast/retry-go/v5"
 )
 
 // Default configuration values
 const (
-	defaultMaxRedirects = 10
-	defaultMaxAttempts  = 3
-	defaultInitialWait  = 500 * time.Millisecond
-	defaultMaxWait      = 5 * time.Second
-	addWordPort         = "1001"
+	defaultMaxRedirects  = 10
+	defaultMaxAttempts   = 3
+	defaultInitialWait   = 500 * time.Millisecond
+	defaultMaxWait       = 5 * time.Second
+	defaultMaxRetryAfter = time.Minute
+	addWordPort          = "1001"
 )
 
 var (
 	errAPIAuth           = errors.New("api authentication error")
+	errAPIUnauthorized   = errors.New("api session is not authorized")
 	errAPIResponseStatus = errors.New("unexpected response status code")
 	errAPIRequestTimeout = errors.New("api request timeout")
+	errAPIRetryAfter     = errors.New("server asked to retry later than allowed")
 )
 
 // RetryConfig holds retry configuration for API requests.
@@ -39,6 +44,23 @@ type RetryConfig struct {
 	MaxAttempts int
 	InitialWait time.Duration
 	MaxWait     time.Duration
+	// MaxRetryAfter is the longest delay requested by the server with the Retry-After
+	// header that is waited for. Requests asking for longer delays fail without retrying.
+	MaxRetryAfter time.Duration
+}
+
+// retryAfterError is a retryable error with the delay requested by the server.
+type retryAfterError struct {
+	err   error
+	delay time.Duration
+}
+
+func (e retryAfterError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", e.err, e.delay)
+}
+
+func (e retryAfterError) Unwrap() error {
+	return e.err
 }
 
 // Config holds configuration for API client.
@@ -48,6 +70,12 @@ type Config struct {
 	MaxIdleConns        int
 	MaxIdleConnsPerHost int
 	Retry               RetryConfig
+	Endpoints           Endpoints
+	// Languages are the learned and the native languages. Zero means lang.DefaultPair.
+	Languages lang.Pair
+	// Redactor removes credentials and cookies from debug dumps.
+	// Nil means httpclient.NewRedactor with the default sensitive headers and fields.
+	Redactor *httpclient.Redactor
 }
 
 // DefaultConfig returns a Config with sensible defaults.
@@ -61,7 +89,11 @@ func DefaultConfig() Config {
 			MaxAttempts: defaultMaxAttempts,
 			InitialWait: defaultInitialWait,
 			MaxWait:     defaultMaxWait,
+
+			MaxRetryAfter: defaultMaxRetryAfter,
 		},
+		Endpoints: DefaultEndpoints(),
+		Languages: lang.DefaultPair,
 	}
 }
 
@@ -73,6 +105,9 @@ func DefaultConfig() Config {
 type Client interface {
 	TranslateWord(ctx context.Context, word string) OperationResult
 	AddWord(ctx context.Context, word string, translate string) OperationResult
+	DeleteWord(ctx context.Context, word string) OperationResult
+	UpdateTranslation(ctx context.Context, word string, translates []string) OperationResult
+	ListDictionary(ctx context.Context, query DictionaryQuery) (DictionaryPage, error)
 	Auth(ctx context.Context) error
 }
 
@@ -80,9 +115,30 @@ type API struct {
 	client      *http.Client
 	Email       string
 	Password    string //nolint:gosec // false positive: credential field name is intentional
+	endpoints   Endpoints
 	Debug       bool
+	redactor    *httpclient.Redactor
+	languages   lang.Pair
 	timeout     time.Duration
 	retryConfig RetryConfig
+	auth        authState
+}
+
+// authState serialises re-authentication of concurrent requests.
+// generation is bumped after every re-authentication attempt, so requests
+// that lost the session before it are replayed without authenticating again.
+type authState struct {
+	err        error
+	generation uint64
+	mu         sync.Mutex
+}
+
+type response struct {
+	body       []byte
+	url        string
+	statusCode int
+	retryAfter time.Duration
+	html       bool
 }
 
 type requestParams struct {
@@ -106,6 +162,27 @@ func checkAuthError(body []byte) error {
 	return nil
 }
 
+// isUnauthorizedBody reports whether an API response body says the session is not authorized.
+func isUnauthorizedBody(body []byte) bool {
+	if len(body) == 0 {
+		return false
+	}
+	res := apiError{}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return false
+	}
+	return res.ErrorCode == http.StatusUnauthorized || res.ErrorCode == http.StatusForbidden
+}
+
+// isHTMLResponse reports whether the server answered with a web page instead of JSON.
+// An expired session is often redirected to the login page.
+func isHTMLResponse(contentType string, body []byte) bool {
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(contentType)), "text/html") {
+		return true
+	}
+	return bytes.HasPrefix(bytes.TrimSpace(body), []byte("<"))
+}
+
 // New creates an API client with the provided HTTP client.
 // The HTTP client should be created with httpclient.NewWithJar for proper cookie handling.
 func New(email string, password string, debug bool, cfg Config, client *http.Client) *API {
@@ -117,12 +194,23 @@ func New(email string, password string, debug bool, cfg Config, client *http.Cli
 	cfg.Retry.MaxAttempts = cmp.Or(cfg.Retry.MaxAttempts, defaultMaxAttempts)
 	cfg.Retry.InitialWait = cmp.Or(cfg.Retry.InitialWait, defaultInitialWait)
 	cfg.Retry.MaxWait = cmp.Or(cfg.Retry.MaxWait, defaultMaxWait)
+	cfg.Retry.MaxRetryAfter = cmp.Or(cfg.Retry.MaxRetryAfter, defaultMaxRetryAfter)
+	cfg.Endpoints = cfg.Endpoints.withDefaults(DefaultEndpoints())
+	if cfg.Languages == (lang.Pair{}) {
+		cfg.Languages = lang.DefaultPair
+	}
+	if cfg.Redactor == nil {
+		cfg.Redactor = httpclient.NewRedactor(nil, nil)
+	}
 
 	return &API{
 		Email:       email,
 		Password:    password,
 		Debug:       debug,
+		redactor:    cfg.Redactor,
+		languages:   cfg.Languages,
 		client:      client,
+		endpoints:   cfg.Endpoints,
 		timeout:     cfg.Timeout,
 		retryConfig: cfg.Retry,
 	}
@@ -139,7 +227,7 @@ func (a *API) Auth(ctx context.Context) error {
 	}
 	responseBody, err := a.request(ctx, requestParams{
 		method: "POST",
-		url:    authURL,
+		url:    a.endpoints.Auth,
 		body:   jsonValue,
 	})
 	if err != nil {
@@ -148,26 +236,6 @@ func (a *API) Auth(ctx context.Context) error {
 	return checkAuthError(responseBody)
 }
 
-func debugRequest(request *http.Request) {
-	dump, err := httputil.DumpRequestOut(request, true)
-	if err != nil {
-		slog.Error("cannot dump http request", "error", err)
-	} else {
-		//nolint:gosec // debug output intentionally logs full HTTP request dump
-		slog.Debug(string(dump))
-	}
-}
-
-func debugResponse(response *http.Response) {
-	dump, err := httputil.DumpResponse(response, true)
-	if err != nil {
-		slog.Error("cannot dump http response", "error", err)
-	} else {
-		//nolint:gosec // debug output intentionally logs full HTTP response dump
-		slog.Debug(string(dump))
-	}
-}
-
 // isRetryable checks if an error or status code should trigger a retry.
 func isRetryable(err error, statusCode int) bool {
 	// Network errors are retryable
@@ -185,16 +253,27 @@ func isRetryable(err error, statusCode int) bool {
 	return false
 }
 
+// retryDelay backs off exponentially up to MaxWait unless the server asked
+// to retry after a given delay. Delays longer than MaxRetryAfter are not retried.
+func (a *API) retryDelay(n uint, err error, config retry.DelayContext) time.Duration {
+	if retryAfter, ok := errors.AsType[retryAfterError](err); ok {
+		return retryAfter.delay
+	}
+	delay := retry.BackOffDelay(n, err, config)
+	if a.retryConfig.MaxWait > 0 {
+		delay = min(delay, a.retryConfig.MaxWait)
+	}
+	return delay
+}
+
 func (a *API) request(ctx context.Context, params requestParams) ([]byte, error) {
-	var body []byte
-	var lastErr error
+	var resp response
 
 	retrier := retry.New(
 		retry.Context(ctx),
 		retry.Attempts(uint(a.retryConfig.MaxAttempts)), //nolint:gosec // G115: safe conversion, value is always small positive int
 		retry.Delay(a.retryConfig.InitialWait),
-		retry.MaxDelay(a.retryConfig.MaxWait),
-		retry.DelayType(retry.BackOffDelay),
+		retry.DelayType(a.retryDelay),
 		retry.OnRetry(func(n uint, err error) {
 			slog.Debug("retrying request", "attempt", n+1, "error", err, "url", params.url)
 		}),
@@ -202,20 +281,37 @@ func (a *API) request(ctx context.Context, params requestParams) ([]byte, error)
 
 	err := retrier.Do(
 		func() error {
-			var statusCode int
-			body, statusCode, lastErr = a.doRequest(ctx, params)
-			if lastErr != nil {
-				return lastErr
+			var err error
+			resp, err = a.doRequest(ctx, params)
+			if err != nil {
+				return err
+			}
+			statusCode := resp.statusCode
+			if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+				return retry.Unrecoverable(fmt.Errorf("%w: status code: %d", errAPIUnauthorized, statusCode))
+			}
+			if statusCode == http.StatusOK && resp.html {
+				return retry.Unrecoverable(fmt.Errorf("%w: html response from %s", errAPIUnauthorized, resp.url))
 			}
 			if statusCode != http.StatusOK && isRetryable(nil, statusCode) {
-				return fmt.Errorf("%w: status code: %d", errAPIResponseStatus, statusCode)
+				err = fmt.Errorf("%w: status code: %d", errAPIResponseStatus, statusCode)
+				if maxDelay := cmp.Or(a.retryConfig.MaxRetryAfter, defaultMaxRetryAfter); resp.retryAfter > maxDelay {
+					// An earlier retry would be throttled again.
+					return retry.Unrecoverable(fmt.Errorf(
+						"%w: %w: retry after %s, limit %s", errAPIRetryAfter, err, resp.retryAfter, maxDelay,
+					))
+				}
+				if resp.retryAfter > 0 {
+					return retryAfterError{err: err, delay: resp.retryAfter}
+				}
+				return err
 			}
 			if statusCode != http.StatusOK {
 				return retry.Unrecoverable(fmt.Errorf(
 					"%w: status code: %d\nbody:\n%s",
 					errAPIResponseStatus,
 					statusCode,
-					string(body),
+					string(resp.body),
 				))
 			}
 			return nil
@@ -225,11 +321,11 @@ func (a *API) request(ctx context.Context, params requestParams) ([]byte, error)
 	if err != nil {
 		return nil, err
 	}
-	return body, nil
+	return resp.body, nil
 }
 
 // doRequest performs a single HTTP request without retry logic.
-func (a *API) doRequest(ctx context.Context, params requestParams) ([]byte, int, error) {
+func (a *API) doRequest(ctx context.Context, params requestParams) (response, error) {
 	ctx, cancel := context.WithTimeoutCause(ctx, a.timeout, errAPIRequestTimeout)
 	defer cancel()
 
@@ -239,7 +335,7 @@ func (a *API) doRequest(ctx context.Context, params requestParams) ([]byte, int,
 	}
 	req, err := http.NewRequestWithContext(ctx, params.method, params.url, requestBody)
 	if err != nil {
-		return nil, 0, err
+		return response{}, err
 	}
 	if len(params.query) > 0 {
 		req.URL.RawQuery = params.query
@@ -257,14 +353,14 @@ func (a *API) doRequest(ctx context.Context, params requestParams) ([]byte, int,
 	}
 
 	if a.Debug {
-		debugRequest(req)
+		a.redactor.LogRequest(req, true)
 	}
 	resp, err := a.client.Do(req) //nolint:gosec // URL is internal API constant configured by the application
 	if err != nil {
-		return nil, 0, err
+		return response{}, err
 	}
 	if a.Debug {
-		debugResponse(resp)
+		a.redactor.LogResponse(resp, true)
 	}
 	defer func() {
 		dErr := resp.Body.Close()
@@ -272,18 +368,23 @@ func (a *API) doRequest(ctx context.Context, params requestParams) ([]byte, int,
 			slog.Error("cannot close response body", "error", dErr)
 		}
 	}()
-	responseBody, err := io.ReadAll(resp.Body)
+	res := response{statusCode: resp.StatusCode, url: resp.Request.URL.String()}
+	res.retryAfter, _ = httpclient.RetryAfter(resp, time.Now())
+	res.body, err = io.ReadAll(resp.Body)
 	if err != nil {
 		slog.Error("cannot read response body", "error", err)
-		return nil, resp.StatusCode, err
+		return res, err
 	}
-	return responseBody, resp.StatusCode, nil
+	res.html = isHTMLResponse(resp.Header.Get("Content-Type"), res.body)
+	return res, nil
 }
 
 func (a *API) translateRequest(ctx context.Context, word string) ([]byte, error) {
 	values := map[string]any{
 		"text":       word,
 		"apiVersion": apiVersion,
+		"fromLang":   a.languages.Source.Code,
+		"toLang":     a.languages.Target.Code,
 		"ctx": map[string]any{
 			"config": map[string]any{
 				"isCheckData": true,
@@ -297,16 +398,18 @@ func (a *API) translateRequest(ctx context.Context, word string) ([]byte, error)
 	}
 	return a.request(ctx, requestParams{
 		method: "POST",
-		url:    translateURL,
+		url:    a.endpoints.Translate,
 		body:   jsonValue,
 	})
 }
 
 func (a *API) addRequest(ctx context.Context, word string, translate string) ([]byte, error) {
 	values := map[string]string{
-		"word":  word,
-		"tword": translate,
-		"port":  addWordPort,
+		"word":     word,
+		"tword":    translate,
+		"port":     addWordPort,
+		"fromLang": a.languages.Source.Code,
+		"toLang":   a.languages.Target.Code,
 	}
 	jsonValue, err := json.Marshal(values)
 	if err != nil {
@@ -314,13 +417,54 @@ func (a *API) addRequest(ctx context.Context, word string, translate string) ([]
 	}
 	return a.request(ctx, requestParams{
 		method: "POST",
-		url:    addWordURL,
+		url:    a.endpoints.AddWord,
 		body:   jsonValue,
 	})
 }
 
+func (a *API) authGeneration() uint64 {
+	a.auth.mu.Lock()
+	defer a.auth.mu.Unlock()
+	return a.auth.generation
+}
+
+// reauthenticate authenticates unless another request already did it
+// after seen generation, in which case it returns that attempt's result.
+// Concurrent workers losing the session at once authenticate only once.
+func (a *API) reauthenticate(ctx context.Context, seen uint64) error {
+	a.auth.mu.Lock()
+	defer a.auth.mu.Unlock()
+	if a.auth.generation != seen {
+		return a.auth.err
+	}
+	slog.Debug("api session is not authorized, authenticating")
+	a.auth.err = a.Auth(ctx)
+	a.auth.generation++
+	return a.auth.err
+}
+
+// authorized runs an API request and, when the server reports that the session
+// is not authorized (e.g. a restored session has expired), authenticates
+// and replays the request once.
+func (a *API) authorized(ctx context.Context, do func() ([]byte, error)) ([]byte, error) {
+	generation := a.authGeneration()
+	body, err := do()
+	if err == nil && !isUnauthorizedBody(body) {
+		return body, nil
+	}
+	if err != nil && !errors.Is(err, errAPIUnauthorized) {
+		return nil, err
+	}
+	if authErr := a.reauthenticate(ctx, generation); authErr != nil {
+		return nil, authErr
+	}
+	return do()
+}
+
 func (a *API) TranslateWord(ctx context.Context, word string) OperationResult {
-	body, err := a.translateRequest(ctx, word)
+	body, err := a.authorized(ctx, func() ([]byte, error) {
+		return a.translateRequest(ctx, word)
+	})
 	if err != nil {
 		return OperationResult{Error: err}
 	}
@@ -328,7 +472,9 @@ func (a *API) TranslateWord(ctx context.Context, word string) OperationResult {
 }
 
 func (a *API) AddWord(ctx context.Context, word string, translate string) OperationResult {
-	body, err := a.addRequest(ctx, word, translate)
+	body, err := a.authorized(ctx, func() ([]byte, error) {
+		return a.addRequest(ctx, word, translate)
+	})
 	if err != nil {
 		return OperationResult{Error: err}
 	}
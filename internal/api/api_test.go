@@ -2,15 +2,19 @@ package api
 
 import (
 	"context"
+	"encoding/json/v2"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/trezorg/lingualeo/internal/httpclient"
+	"github.com/trezorg/lingualeo/internal/lang"
 )
 
 func TestRequest(t *testing.T) {
@@ -172,6 +176,68 @@ func TestRequestRetriesRetryableStatusCodes(t *testing.T) {
 	assert.Equal(t, `{"status": "ok"}`, string(resp))
 }
 
+func TestRequestHonoursRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+
+	api := &API{
+		client:  server.Client(),
+		timeout: time.Second,
+		retryConfig: RetryConfig{
+			MaxAttempts:   2,
+			InitialWait:   time.Millisecond,
+			MaxWait:       time.Millisecond,
+			MaxRetryAfter: 2 * time.Second,
+		},
+	}
+
+	started := time.Now()
+	resp, err := api.request(t.Context(), requestParams{method: http.MethodGet, url: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, `{"status": "ok"}`, string(resp))
+	assert.GreaterOrEqual(t, time.Since(started), time.Second)
+}
+
+func TestRequestFailsWhenRetryAfterExceedsLimit(t *testing.T) {
+	t.Parallel()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	api := &API{
+		client:  server.Client(),
+		timeout: time.Second,
+		retryConfig: RetryConfig{
+			MaxAttempts:   3,
+			InitialWait:   time.Millisecond,
+			MaxWait:       time.Millisecond,
+			MaxRetryAfter: time.Minute,
+		},
+	}
+
+	_, err := api.request(t.Context(), requestParams{method: http.MethodGet, url: server.URL})
+	require.ErrorIs(t, err, errAPIRetryAfter)
+	assert.ErrorContains(t, err, "retry after 2m0s")
+	assert.Equal(t, 1, attempts)
+}
+
 func TestRequestDoesNotRetryUnrecoverableStatusCodes(t *testing.T) {
 	t.Parallel()
 
@@ -258,6 +324,18 @@ func (m *MockClient) Auth(_ context.Context) error {
 	return nil
 }
 
+func (*MockClient) ListDictionary(_ context.Context, _ DictionaryQuery) (DictionaryPage, error) {
+	return DictionaryPage{}, nil
+}
+
+func (*MockClient) DeleteWord(_ context.Context, word string) OperationResult {
+	return OperationResult{Result: Result{Word: word}}
+}
+
+func (*MockClient) UpdateTranslation(_ context.Context, word string, translates []string) OperationResult {
+	return OperationResult{Result: Result{Word: word, AddWords: translates}}
+}
+
 func TestMockClientImplementsInterface(t *testing.T) {
 	var _ Client = &MockClient{}
 }
@@ -291,3 +369,309 @@ func TestMockClientAddWord(t *testing.T) {
 	result := mock.AddWord(t.Context(), "hello", "привет")
 	require.NoError(t, result.Error)
 }
+
+func TestTranslateWordAuthenticatesAndReplaysOnUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	var authCalls, translateCalls int
+	authorized := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, _ *http.Request) {
+		authCalls++
+		authorized = true
+		_, _ = w.Write([]byte(`{"error_msg":"","error_code":0}`))
+	})
+	mux.HandleFunc("/translate", func(w http.ResponseWriter, _ *http.Request) {
+		translateCalls++
+		if !authorized {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"translate":[{"id":1,"value":"привет","votes":1}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	api := &API{
+		client: server.Client(),
+		endpoints: Endpoints{
+			Auth:      server.URL + "/auth",
+			Translate: server.URL + "/translate",
+		},
+		timeout:     time.Second,
+		retryConfig: RetryConfig{MaxAttempts: 1},
+	}
+
+	res := api.TranslateWord(t.Context(), "hello")
+	require.NoError(t, res.Error)
+	assert.Equal(t, 1, authCalls)
+	assert.Equal(t, 2, translateCalls)
+	require.Len(t, res.Result.Translate, 1)
+	assert.Equal(t, "привет", res.Result.Translate[0].Value)
+}
+
+func TestAddWordAuthenticatesOnUnauthorizedBody(t *testing.T) {
+	t.Parallel()
+
+	var authCalls, addCalls int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, _ *http.Request) {
+		authCalls++
+		_, _ = w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("/add", func(w http.ResponseWriter, _ *http.Request) {
+		addCalls++
+		if authCalls == 0 {
+			_, _ = w.Write([]byte(`{"error_msg":"Unauthorized","error_code":401}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"translate":[]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	api := &API{
+		client: server.Client(),
+		endpoints: Endpoints{
+			Auth:    server.URL + "/auth",
+			AddWord: server.URL + "/add",
+		},
+		timeout:     time.Second,
+		retryConfig: RetryConfig{MaxAttempts: 1},
+	}
+
+	res := api.AddWord(t.Context(), "hello", "привет")
+	require.NoError(t, res.Error)
+	assert.Equal(t, 1, authCalls)
+	assert.Equal(t, 2, addCalls)
+}
+
+func TestConcurrentRequestsReauthenticateOnce(t *testing.T) {
+	t.Parallel()
+
+	var authCalls atomic.Int32
+	var authorized atomic.Bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, _ *http.Request) {
+		authCalls.Add(1)
+		// Keep the auth request in flight so that other workers lose the session meanwhile.
+		time.Sleep(20 * time.Millisecond)
+		authorized.Store(true)
+		_, _ = w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("/translate", func(w http.ResponseWriter, _ *http.Request) {
+		if !authorized.Load() {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"translate":[{"id":1,"value":"привет","votes":1}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	api := &API{
+		client: server.Client(),
+		endpoints: Endpoints{
+			Auth:      server.URL + "/auth",
+			Translate: server.URL + "/translate",
+		},
+		timeout:     time.Second,
+		retryConfig: RetryConfig{MaxAttempts: 1},
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			res := api.TranslateWord(t.Context(), "hello")
+			assert.NoError(t, res.Error)
+		})
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), authCalls.Load())
+}
+
+func TestConcurrentRequestsShareFailedReauthentication(t *testing.T) {
+	t.Parallel()
+
+	var authCalls atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, _ *http.Request) {
+		authCalls.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"error_msg":"Incorrect email or password","error_code":403}`))
+	})
+	mux.HandleFunc("/translate", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	api := &API{
+		client: server.Client(),
+		endpoints: Endpoints{
+			Auth:      server.URL + "/auth",
+			Translate: server.URL + "/translate",
+		},
+		timeout:     time.Second,
+		retryConfig: RetryConfig{MaxAttempts: 1},
+	}
+
+	// All requests lose the session before anyone re-authenticates.
+	generation := api.authGeneration()
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Go(func() {
+			assert.ErrorIs(t, api.reauthenticate(t.Context(), generation), errAPIAuth)
+		})
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), authCalls.Load())
+}
+
+func TestTranslateWordReauthenticatesOnLoginPageRedirect(t *testing.T) {
+	t.Parallel()
+
+	var authCalls, translateCalls int
+	authorized := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, _ *http.Request) {
+		authCalls++
+		authorized = true
+		_, _ = w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("/login", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<!DOCTYPE html><html><body>Sign in</body></html>`))
+	})
+	mux.HandleFunc("/translate", func(w http.ResponseWriter, r *http.Request) {
+		translateCalls++
+		if !authorized {
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"translate":[{"id":1,"value":"привет","votes":1}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	api := &API{
+		client: server.Client(),
+		endpoints: Endpoints{
+			Auth:      server.URL + "/auth",
+			Translate: server.URL + "/translate",
+		},
+		timeout:     time.Second,
+		retryConfig: RetryConfig{MaxAttempts: 3},
+	}
+
+	res := api.TranslateWord(t.Context(), "hello")
+	require.NoError(t, res.Error)
+	assert.Equal(t, 1, authCalls)
+	assert.Equal(t, 2, translateCalls)
+}
+
+func TestIsHTMLResponse(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isHTMLResponse("text/html; charset=utf-8", []byte(`{}`)))
+	assert.True(t, isHTMLResponse("", []byte("\n  <html></html>")))
+	assert.False(t, isHTMLResponse("application/json", []byte(`{"translate":[]}`)))
+	assert.False(t, isHTMLResponse("", nil))
+}
+
+func TestTranslateWordReturnsAuthError(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"error_msg":"Wrong password","error_code":403}`))
+	})
+	mux.HandleFunc("/translate", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	api := &API{
+		client: server.Client(),
+		endpoints: Endpoints{
+			Auth:      server.URL + "/auth",
+			Translate: server.URL + "/translate",
+		},
+		timeout:     time.Second,
+		retryConfig: RetryConfig{MaxAttempts: 1},
+	}
+
+	res := api.TranslateWord(t.Context(), "hello")
+	require.Error(t, res.Error)
+	assert.True(t, errors.Is(res.Error, errAPIAuth))
+}
+
+func TestAuthWithDebugSendsUnredactedBody(t *testing.T) {
+	t.Parallel()
+
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.UnmarshalRead(r.Body, &received))
+		http.SetCookie(w, &http.Cookie{Name: "remember", Value: "token"})
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.Endpoints = EndpointsFromBaseURL(server.URL)
+	api := New("test@example.com", "password", true, cfg, server.Client())
+
+	require.NoError(t, api.Auth(t.Context()))
+	require.Equal(t, map[string]string{"email": "test@example.com", "password": "password"}, received)
+}
+
+func TestTranslateWordSendsLanguagePair(t *testing.T) {
+	t.Parallel()
+
+	var received map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.UnmarshalRead(r.Body, &received))
+		_, _ = w.Write([]byte(`{"translate":[{"value":"olá"}]}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.Endpoints = EndpointsFromBaseURL(server.URL)
+	cfg.Languages = lang.Pair{Source: lang.English, Target: lang.Portuguese}
+	api := New("test@example.com", "password", false, cfg, server.Client())
+
+	require.NoError(t, api.TranslateWord(t.Context(), "hello").Error)
+	require.Equal(t, "en", received["fromLang"])
+	require.Equal(t, "pt", received["toLang"])
+}
+
+func TestNewUsesEndpointsFromBaseURL(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /getTranslates", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"translate":[{"id":1,"value":"привет","votes":1}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.Endpoints = EndpointsFromBaseURL(server.URL + "/")
+	cfg.Retry.MaxAttempts = 1
+	api := New("test@example.com", "password", false, cfg, server.Client())
+
+	res := api.TranslateWord(t.Context(), "hello")
+	require.NoError(t, res.Error)
+	require.Len(t, res.Result.Translate, 1)
+}
+
+func TestNewFillsMissingEndpoints(t *testing.T) {
+	t.Parallel()
+
+	api := New("test@example.com", "password", false, Config{Endpoints: Endpoints{Translate: "http://localhost/translate"}}, http.DefaultClient)
+	defaults := DefaultEndpoints()
+	assert.Equal(t, defaults.Auth, api.endpoints.Auth)
+	assert.Equal(t, "http://localhost/translate", api.endpoints.Translate)
+	assert.Equal(t, defaults.AddWord, api.endpoints.AddWord)
+}
@@ -5,10 +5,14 @@ import (
 )
 
 const (
-	authURL      = "https://lingualeo.com/api/auth"
-	translateURL = "https://api.lingualeo.com/getTranslates"
-	addWordURL   = "https://api.lingualeo.com/addWord"
-	apiVersion   = "1.0.1"
+	defaultSiteURL = "https://lingualeo.com"
+	defaultAPIURL  = "https://api.lingualeo.com"
+	authPath       = "/api/auth"
+	translatePath  = "/getTranslates"
+	addWordPath    = "/addWord"
+	dictionaryPath = "/GetWords"
+	setWordsPath   = "/SetWords"
+	apiVersion     = "1.0.1"
 )
 
 var (
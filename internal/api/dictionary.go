@@ -0,0 +1,176 @@
+package api
+
+import (
+	"context"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultDictionaryPerPage = 100
+	dictionaryDateLayout     = time.DateOnly
+)
+
+var (
+	errLearningStatus = errors.New("unknown learning status")
+	errDictionary     = errors.New("cannot list dictionary")
+)
+
+// LearningStatus is the learning progress of a word in the user's dictionary.
+type LearningStatus string
+
+const (
+	StatusAny      LearningStatus = ""
+	StatusNew      LearningStatus = "new"
+	StatusLearning LearningStatus = "learning"
+	StatusLearned  LearningStatus = "learned"
+)
+
+// LearningStatuses lists the statuses accepted by ParseLearningStatus.
+var LearningStatuses = []LearningStatus{StatusNew, StatusLearning, StatusLearned}
+
+// ParseLearningStatus converts a string into LearningStatus. An empty string means any status.
+func ParseLearningStatus(s string) (LearningStatus, error) {
+	status := LearningStatus(s)
+	switch status {
+	case StatusAny, StatusNew, StatusLearning, StatusLearned:
+		return status, nil
+	default:
+		return StatusAny, fmt.Errorf("%w: %s, allowed: %v", errLearningStatus, s, LearningStatuses)
+	}
+}
+
+// DictionaryQuery selects a page of the user's dictionary.
+type DictionaryQuery struct {
+	// AddedAfter and AddedBefore limit words by the day they were added. Zero means no limit.
+	AddedAfter  time.Time
+	AddedBefore time.Time
+	Status      LearningStatus
+	// Page is 1-based.
+	Page    int
+	PerPage int
+}
+
+// DictionaryTranslation is a translation of a dictionary word.
+type DictionaryTranslation struct {
+	Value   string `json:"tr"`
+	Picture string `json:"pic_url"`
+	ID      int    `json:"id"`
+}
+
+// DictionaryWord is a word from the user's dictionary.
+type DictionaryWord struct {
+	Word          string                  `json:"wordValue"`
+	Transcription string                  `json:"transcription"`
+	SoundURL      string                  `json:"sound_url"`
+	Status        LearningStatus          `json:"learningStatus"`
+	Translations  []DictionaryTranslation `json:"translations"`
+	Created       int64                   `json:"created"`
+	ID            int                     `json:"id"`
+}
+
+// Added returns the time the word was added into the dictionary.
+func (w DictionaryWord) Added() time.Time {
+	return time.Unix(w.Created, 0)
+}
+
+// Result converts the dictionary word into a translation result,
+// so it can be rendered the same way as translated words.
+func (w DictionaryWord) Result() Result {
+	res := Result{
+		Word:          w.Word,
+		Transcription: w.Transcription,
+		SoundURL:      w.SoundURL,
+		Exists:        true,
+		Translate:     make([]Word, 0, len(w.Translations)),
+	}
+	for _, tr := range w.Translations {
+		res.Translate = append(res.Translate, Word{
+			ID:        tr.ID,
+			Value:     tr.Value,
+			Translate: tr.Value,
+			Picture:   tr.Picture,
+			Exists:    true,
+		})
+	}
+	return res
+}
+
+// DictionaryPage is a page of the user's dictionary.
+type DictionaryPage struct {
+	ErrorMsg string           `json:"error_msg"`
+	Words    []DictionaryWord `json:"data"`
+	Total    int              `json:"total"`
+	Page     int              `json:"-"`
+	PerPage  int              `json:"-"`
+}
+
+// HasMore reports whether there are pages after this one.
+func (p DictionaryPage) HasMore() bool {
+	return p.Page*p.PerPage < p.Total && len(p.Words) > 0
+}
+
+// NextQuery returns the query for the page after this one.
+func (p DictionaryPage) NextQuery(query DictionaryQuery) DictionaryQuery {
+	query.Page = p.Page + 1
+	query.PerPage = p.PerPage
+	return query
+}
+
+func (q DictionaryQuery) withDefaults() DictionaryQuery {
+	q.Page = max(q.Page, 1)
+	if q.PerPage <= 0 {
+		q.PerPage = defaultDictionaryPerPage
+	}
+	return q
+}
+
+func (a *API) dictionaryRequest(ctx context.Context, query DictionaryQuery) ([]byte, error) {
+	values := map[string]any{
+		"apiVersion": apiVersion,
+		"mode":       "basic",
+		"page":       query.Page,
+		"perPage":    query.PerPage,
+	}
+	if query.Status != StatusAny {
+		values["status"] = string(query.Status)
+	}
+	if !query.AddedAfter.IsZero() {
+		values["dateFrom"] = query.AddedAfter.Format(dictionaryDateLayout)
+	}
+	if !query.AddedBefore.IsZero() {
+		values["dateTo"] = query.AddedBefore.Format(dictionaryDateLayout)
+	}
+	jsonValue, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	return a.request(ctx, requestParams{
+		method: "POST",
+		url:    a.endpoints.Dictionary,
+		body:   jsonValue,
+	})
+}
+
+// ListDictionary returns a page of words from the user's dictionary.
+func (a *API) ListDictionary(ctx context.Context, query DictionaryQuery) (DictionaryPage, error) {
+	query = query.withDefaults()
+	body, err := a.authorized(ctx, func() ([]byte, error) {
+		return a.dictionaryRequest(ctx, query)
+	})
+	if err != nil {
+		return DictionaryPage{}, err
+	}
+	page := DictionaryPage{}
+	if err = json.Unmarshal(body, &page); err != nil {
+		return DictionaryPage{}, fmt.Errorf("%w: %w", errDictionary, err)
+	}
+	if len(page.ErrorMsg) > 0 {
+		return DictionaryPage{}, fmt.Errorf("%w: %s", errDictionary, page.ErrorMsg)
+	}
+	page.Page = query.Page
+	page.PerPage = query.PerPage
+	return page, nil
+}
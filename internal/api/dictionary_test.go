@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json/v2"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLearningStatus(t *testing.T) {
+	for _, value := range []string{"", "new", "learning", "learned"} {
+		status, err := ParseLearningStatus(value)
+		require.NoError(t, err)
+		assert.Equal(t, LearningStatus(value), status)
+	}
+
+	_, err := ParseLearningStatus("forgotten")
+	require.True(t, errors.Is(err, errLearningStatus))
+}
+
+func TestListDictionary(t *testing.T) {
+	t.Parallel()
+
+	var request map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.UnmarshalRead(r.Body, &request))
+		_, _ = w.Write([]byte(`{"error_msg":"","total":3,"data":[
+			{"id":1,"wordValue":"hello","transcription":"həˈləʊ","learningStatus":"learning","created":1704153600,
+			 "translations":[{"id":7,"tr":"привет","pic_url":"https://example.com/1.png"}]},
+			{"id":2,"wordValue":"world","learningStatus":"learning","created":1704067200,"translations":[{"id":8,"tr":"мир"}]}
+		]}`))
+	}))
+	defer server.Close()
+
+	api := &API{
+		client:      server.Client(),
+		endpoints:   Endpoints{Dictionary: server.URL},
+		timeout:     time.Second,
+		retryConfig: RetryConfig{MaxAttempts: 1},
+	}
+	page, err := api.ListDictionary(t.Context(), DictionaryQuery{
+		Status:     StatusLearning,
+		AddedAfter: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		PerPage:    2,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "learning", request["status"])
+	assert.Equal(t, "2024-01-01", request["dateFrom"])
+	assert.NotContains(t, request, "dateTo")
+	assert.InDelta(t, 1, request["page"], 0)
+	assert.InDelta(t, 2, request["perPage"], 0)
+
+	require.Len(t, page.Words, 2)
+	assert.Equal(t, 3, page.Total)
+	assert.True(t, page.HasMore())
+	assert.Equal(t, DictionaryQuery{Page: 2, PerPage: 2, Status: StatusLearning}, page.NextQuery(DictionaryQuery{Status: StatusLearning}))
+	assert.Equal(t, time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC), page.Words[0].Added().UTC())
+
+	res := page.Words[0].Result()
+	assert.Equal(t, "hello", res.Word)
+	assert.True(t, res.InDictionary())
+	require.Len(t, res.Translate, 1)
+	assert.Equal(t, "привет", res.Translate[0].Value)
+	assert.Equal(t, "https://example.com/1.png", res.Translate[0].Picture)
+}
+
+func TestListDictionaryReturnsErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"error_msg":"Dictionary is not available"}`))
+	}))
+	defer server.Close()
+
+	api := &API{
+		client:      server.Client(),
+		endpoints:   Endpoints{Dictionary: server.URL},
+		timeout:     time.Second,
+		retryConfig: RetryConfig{MaxAttempts: 1},
+	}
+	_, err := api.ListDictionary(t.Context(), DictionaryQuery{})
+	require.True(t, errors.Is(err, errDictionary))
+	assert.Contains(t, err.Error(), "Dictionary is not available")
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"cmp"
+	"strings"
+)
+
+// Endpoints holds the URLs of the Lingualeo API endpoints.
+type Endpoints struct {
+	Auth       string
+	Translate  string
+	AddWord    string
+	Dictionary string
+	SetWords   string
+}
+
+// DefaultEndpoints returns the public Lingualeo endpoints.
+func DefaultEndpoints() Endpoints {
+	return Endpoints{
+		Auth:       defaultSiteURL + authPath,
+		Translate:  defaultAPIURL + translatePath,
+		AddWord:    defaultAPIURL + addWordPath,
+		Dictionary: defaultAPIURL + dictionaryPath,
+		SetWords:   defaultAPIURL + setWordsPath,
+	}
+}
+
+// EndpointsFromBaseURL returns endpoints served under a single base URL,
+// e.g. a mirror, a proxy gateway or a local stand-in server.
+func EndpointsFromBaseURL(baseURL string) Endpoints {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return Endpoints{
+		Auth:       baseURL + authPath,
+		Translate:  baseURL + translatePath,
+		AddWord:    baseURL + addWordPath,
+		Dictionary: baseURL + dictionaryPath,
+		SetWords:   baseURL + setWordsPath,
+	}
+}
+
+// withDefaults fills empty endpoints from defaults.
+func (e Endpoints) withDefaults(defaults Endpoints) Endpoints {
+	return Endpoints{
+		Auth:       cmp.Or(e.Auth, defaults.Auth),
+		Translate:  cmp.Or(e.Translate, defaults.Translate),
+		AddWord:    cmp.Or(e.AddWord, defaults.AddWord),
+		Dictionary: cmp.Or(e.Dictionary, defaults.Dictionary),
+		SetWords:   cmp.Or(e.SetWords, defaults.SetWords),
+	}
+}
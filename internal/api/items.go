@@ -49,6 +49,13 @@ type Word struct {
 	Exists    convertibleBoolean `json:"ut"`
 }
 
+// WordForm is an inflected form of the translated word
+type WordForm struct {
+	Word string `json:"word"`
+	// Type is the part of speech or the grammatical form, e.g. "прил." or "past participle"
+	Type string `json:"type"`
+}
+
 // OperationResult represents operation result
 type OperationResult struct {
 	Error  error
@@ -71,11 +78,18 @@ type Result struct {
 	Transcription            string             `json:"transcription"`
 	ErrorMsg                 string             `json:"error_msg"`
 	Pos                      string             `json:"pos"`
+	Picture                  string             `json:"pic_url"`
+	TranslateSource          string             `json:"translate_source"`
 	AddWords                 []string           `json:"-"`
 	Translate                []Word             `json:"translate"`
+	WordForms                []WordForm         `json:"word_forms"`
+	WordID                   int                `json:"word_id"`
+	WordTop                  int                `json:"word_top"`
 	Exists                   convertibleBoolean `json:"is_user"`
 	DirectionEnglish         bool               `json:"directionEnglish"`
 	InvertTranslateDirection bool               `json:"invertTranslateDirection"`
+	// existing are the translations in the dictionary removed by FilterTranslations
+	existing []string
 }
 
 // ResultError wraps translation results that failed on the API side.
@@ -126,9 +140,36 @@ func (r *Result) SetTranslation(translates []string) {
 	r.AddWords = slice.Unique(translates)
 }
 
+// FilterTranslations keeps at most top translations having at least minVotes votes.
+// Translations are sorted by votes, so the most voted ones are kept. Zero disables a limit.
+func (r *Result) FilterTranslations(top int, minVotes int) {
+	kept := make([]Word, 0, len(r.Translate))
+	for _, word := range r.Translate {
+		if word.Votes >= minVotes && (top <= 0 || len(kept) < top) {
+			kept = append(kept, word)
+			continue
+		}
+		if bool(word.Exists) {
+			r.existing = append(r.existing, word.Value)
+		}
+	}
+	r.Translate = kept
+}
+
+// HasExistingTranslation reports whether the translation is already in the dictionary,
+// including translations removed by FilterTranslations.
+func (r *Result) HasExistingTranslation(translation string) bool {
+	for _, word := range r.Translate {
+		if word.Value == translation && bool(word.Exists) {
+			return true
+		}
+	}
+	return slices.Contains(r.existing, translation)
+}
+
 // InDictionary checks either word is already has been added into the dictionary
 func (r *Result) InDictionary() bool {
-	if bool(r.Exists) {
+	if bool(r.Exists) || len(r.existing) > 0 {
 		return true
 	}
 	for _, word := range r.Translate {
@@ -143,9 +184,17 @@ func (r *Result) HasError() bool {
 	return len(r.ErrorMsg) > 0
 }
 
+// InTargetLanguage reports whether the word is in the native language rather than the learned one.
+// Lingualeo gives transcriptions only for words of the learned language.
+func (r *Result) InTargetLanguage() bool {
+	return r.Transcription == ""
+}
+
 // IsRussian either word in in Russian language
+//
+// Deprecated: Use InTargetLanguage instead.
 func (r *Result) IsRussian() bool {
-	return r.Transcription == ""
+	return r.InTargetLanguage()
 }
 
 // NoResult negative operation result
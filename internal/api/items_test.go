@@ -3,10 +3,12 @@ package api
 import (
 	"encoding/json/v2"
 	"errors"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/trezorg/lingualeo/internal/httpclient"
 )
 
 func TestConvertibleBooleanUnmarshalJSON(t *testing.T) {
@@ -182,6 +184,32 @@ func TestResultFromResponse(t *testing.T) {
 	}
 }
 
+func TestResultFromResponseDecodesFullPayload(t *testing.T) {
+	body := `{"error_msg":"","translate_source":"base","is_user":0,
+		"word_forms":[{"word":"accommodations","type":"мн. ч."}],
+		"pic_url":"https://example.com/top.png",
+		"translate":[{"id":1,"value":"жильё","votes":10,"pic_url":"https://example.com/1.png"}],
+		"transcription":"əkəədˈeɪːʃən","word_id":102085,"word_top":3,
+		"sound_url":"https://example.com/sound.mp3"}`
+
+	r := Result{Word: "accommodation"}
+	require.NoError(t, r.FromResponse([]byte(body)))
+
+	assert.Equal(t, []WordForm{{Word: "accommodations", Type: "мн. ч."}}, r.WordForms)
+	assert.Equal(t, "https://example.com/top.png", r.Picture)
+	assert.Equal(t, 102085, r.WordID)
+	assert.Equal(t, 3, r.WordTop)
+	assert.Equal(t, "base", r.TranslateSource)
+	assert.Equal(t, "https://example.com/1.png", r.Translate[0].Picture)
+}
+
+func TestResultHasExistingTranslation(t *testing.T) {
+	r := Result{Translate: []Word{{Value: "test"}, {Value: "check", Exists: true}}}
+	assert.True(t, r.HasExistingTranslation("check"))
+	assert.False(t, r.HasExistingTranslation("test"))
+	assert.False(t, r.HasExistingTranslation("probe"))
+}
+
 func TestResultInDictionary(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -298,6 +326,43 @@ func TestResultSetTranslation(t *testing.T) {
 	assert.Equal(t, []string{"test1", "test2", "test3"}, r.AddWords)
 }
 
+func TestResultFilterTranslations(t *testing.T) {
+	words := []Word{{Value: "check", Votes: 10}, {Value: "test", Votes: 5}, {Value: "probe", Votes: 1}}
+	tests := []struct {
+		name     string
+		want     []string
+		top      int
+		minVotes int
+	}{
+		{name: "no limits", want: []string{"check", "test", "probe"}},
+		{name: "top", top: 2, want: []string{"check", "test"}},
+		{name: "min votes", minVotes: 5, want: []string{"check", "test"}},
+		{name: "both", top: 1, minVotes: 5, want: []string{"check"}},
+		{name: "nothing left", minVotes: 11, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Result{Translate: slices.Clone(words)}
+			r.FilterTranslations(tt.top, tt.minVotes)
+			values := make([]string, 0, len(r.Translate))
+			for _, word := range r.Translate {
+				values = append(values, word.Value)
+			}
+			assert.Equal(t, tt.want, values)
+		})
+	}
+}
+
+func TestResultHasExistingTranslationRemovedByFilter(t *testing.T) {
+	r := Result{Translate: []Word{{Value: "check", Votes: 10}, {Value: "test", Votes: 1, Exists: true}}}
+	r.FilterTranslations(1, 0)
+	assert.Len(t, r.Translate, 1)
+	assert.True(t, r.HasExistingTranslation("test"))
+	assert.False(t, r.HasExistingTranslation("check"))
+	assert.True(t, r.InDictionary())
+}
+
 func TestResultHasError(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -346,3 +411,28 @@ func TestOpResultFromBody(t *testing.T) {
 	assert.Equal(t, "testword", result.Result.Word)
 	assert.NoError(t, result.Error)
 }
+
+func TestTranslateWordDecodesRecordedPayloads(t *testing.T) {
+	t.Parallel()
+
+	cassette, err := httpclient.NewCassette("testdata/translate.yaml", httpclient.CassetteReplay, nil)
+	require.NoError(t, err)
+	httpClient, err := httpclient.New(httpclient.Config{Cassette: cassette})
+	require.NoError(t, err)
+	client := New("test@example.com", "", false, DefaultConfig(), httpClient)
+
+	res := client.TranslateWord(t.Context(), "accommodation")
+	require.NoError(t, res.Error)
+	assert.True(t, res.Result.InDictionary())
+	assert.Equal(t, "əkɒməˈdeɪʃn", res.Result.Transcription)
+	assert.Equal(t, []string{"жильё", "размещение"}, []string{res.Result.Translate[0].Value, res.Result.Translate[1].Value})
+	assert.True(t, bool(res.Result.Translate[0].Exists))
+	assert.Equal(t, 4812, res.Result.Translate[0].Votes)
+	assert.Equal(t, []WordForm{{Word: "accommodations", Type: "мн. ч."}}, res.Result.WordForms)
+	assert.Equal(t, 102085, res.Result.WordID)
+
+	res = client.TranslateWord(t.Context(), "qwertyuiop")
+	require.NoError(t, res.Error)
+	assert.Empty(t, res.Result.Translate)
+	assert.False(t, res.Result.InDictionary())
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"sync"
+	"time"
+)
+
+// Middleware decorates a Client, e.g. with caching, logging or metrics.
+type Middleware func(Client) Client
+
+// Chain wraps client with middlewares. The first middleware is the outermost one,
+// i.e. it sees every call first and its result last. Nil middlewares are skipped.
+func Chain(client Client, middlewares ...Middleware) Client {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] != nil {
+			client = middlewares[i](client)
+		}
+	}
+	return client
+}
+
+// Operation names used by the built-in middlewares.
+const (
+	OpTranslate         = "translate"
+	OpAddWord           = "add_word"
+	OpDeleteWord        = "delete_word"
+	OpUpdateTranslation = "update_translation"
+	OpListDictionary    = "list_dictionary"
+	OpAuth              = "auth"
+)
+
+// observedClient calls observe after every call of the wrapped client.
+type observedClient struct {
+	next    Client
+	observe func(ctx context.Context, op string, word string, elapsed time.Duration, err error)
+}
+
+func (c observedClient) TranslateWord(ctx context.Context, word string) OperationResult {
+	started := time.Now()
+	res := c.next.TranslateWord(ctx, word)
+	c.observe(ctx, OpTranslate, word, time.Since(started), res.Error)
+	return res
+}
+
+func (c observedClient) AddWord(ctx context.Context, word string, translate string) OperationResult {
+	started := time.Now()
+	res := c.next.AddWord(ctx, word, translate)
+	c.observe(ctx, OpAddWord, word, time.Since(started), res.Error)
+	return res
+}
+
+func (c observedClient) DeleteWord(ctx context.Context, word string) OperationResult {
+	started := time.Now()
+	res := c.next.DeleteWord(ctx, word)
+	c.observe(ctx, OpDeleteWord, word, time.Since(started), res.Error)
+	return res
+}
+
+func (c observedClient) UpdateTranslation(ctx context.Context, word string, translates []string) OperationResult {
+	started := time.Now()
+	res := c.next.UpdateTranslation(ctx, word, translates)
+	c.observe(ctx, OpUpdateTranslation, word, time.Since(started), res.Error)
+	return res
+}
+
+func (c observedClient) ListDictionary(ctx context.Context, query DictionaryQuery) (DictionaryPage, error) {
+	started := time.Now()
+	page, err := c.next.ListDictionary(ctx, query)
+	c.observe(ctx, OpListDictionary, "", time.Since(started), err)
+	return page, err
+}
+
+func (c observedClient) Auth(ctx context.Context) error {
+	started := time.Now()
+	err := c.next.Auth(ctx)
+	c.observe(ctx, OpAuth, "", time.Since(started), err)
+	return err
+}
+
+// Logging logs every call with its duration and error at debug level.
+// A nil logger means slog.Default at the time of the call.
+func Logging(logger *slog.Logger) Middleware {
+	return func(next Client) Client {
+		return observedClient{
+			next: next,
+			observe: func(ctx context.Context, op string, word string, elapsed time.Duration, err error) {
+				l := logger
+				if l == nil {
+					l = slog.Default()
+				}
+				attrs := []any{"operation", op, "elapsed", elapsed}
+				if word != "" {
+					attrs = append(attrs, "word", word)
+				}
+				if err != nil {
+					attrs = append(attrs, "error", err)
+				}
+				l.DebugContext(ctx, "api call", attrs...)
+			},
+		}
+	}
+}
+
+// OperationStats are the counters of a single operation collected by Metrics.
+type OperationStats struct {
+	Calls   int
+	Errors  int
+	Elapsed time.Duration
+}
+
+// Metrics counts calls, errors and time spent per operation.
+// The zero value is ready to use.
+type Metrics struct {
+	stats map[string]OperationStats
+	mu    sync.Mutex
+}
+
+// Middleware returns a middleware recording calls into m.
+func (m *Metrics) Middleware() Middleware {
+	return func(next Client) Client {
+		return observedClient{
+			next: next,
+			observe: func(_ context.Context, op string, _ string, elapsed time.Duration, err error) {
+				m.mu.Lock()
+				defer m.mu.Unlock()
+				if m.stats == nil {
+					m.stats = make(map[string]OperationStats)
+				}
+				s := m.stats[op]
+				s.Calls++
+				s.Elapsed += elapsed
+				if err != nil {
+					s.Errors++
+				}
+				m.stats[op] = s
+			},
+		}
+	}
+}
+
+// Snapshot returns a copy of the collected counters keyed by operation.
+func (m *Metrics) Snapshot() map[string]OperationStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return maps.Clone(m.stats)
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// namedClient appends its name to calls before delegating to the wrapped client.
+type namedClient struct {
+	Client
+	calls *[]string
+	name  string
+}
+
+func (c namedClient) TranslateWord(ctx context.Context, word string) OperationResult {
+	*c.calls = append(*c.calls, c.name)
+	return c.Client.TranslateWord(ctx, word)
+}
+
+func TestChainAppliesFirstMiddlewareOutermost(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	named := func(name string) Middleware {
+		return func(next Client) Client {
+			return namedClient{Client: next, calls: &calls, name: name}
+		}
+	}
+
+	client := Chain(&MockClient{}, named("first"), nil, named("second"))
+	client.TranslateWord(t.Context(), "hello")
+	assert.Equal(t, []string{"first", "second"}, calls)
+}
+
+func TestMetricsCountsCallsAndErrors(t *testing.T) {
+	t.Parallel()
+
+	metrics := &Metrics{}
+	failed := errors.New("failed")
+	client := Chain(&MockClient{AddResult: OperationResult{Error: failed}}, metrics.Middleware())
+
+	client.TranslateWord(t.Context(), "hello")
+	client.TranslateWord(t.Context(), "world")
+	client.AddWord(t.Context(), "hello", "привет")
+	require.NoError(t, client.Auth(t.Context()))
+
+	stats := metrics.Snapshot()
+	assert.Equal(t, 2, stats[OpTranslate].Calls)
+	assert.Zero(t, stats[OpTranslate].Errors)
+	assert.Equal(t, 1, stats[OpAddWord].Calls)
+	assert.Equal(t, 1, stats[OpAddWord].Errors)
+	assert.Equal(t, 1, stats[OpAuth].Calls)
+	assert.NotContains(t, stats, OpListDictionary)
+}
+
+func TestLoggingLogsCalls(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := Chain(&MockClient{AddResult: OperationResult{Error: errors.New("failed")}}, Logging(logger))
+
+	client.TranslateWord(t.Context(), "hello")
+	client.AddWord(t.Context(), "world", "мир")
+
+	out := buf.String()
+	assert.Contains(t, out, "operation=translate")
+	assert.Contains(t, out, "word=hello")
+	assert.Contains(t, out, "operation=add_word")
+	assert.Contains(t, out, "error=failed")
+}
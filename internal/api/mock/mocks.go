@@ -152,6 +152,129 @@ func (_c *Mock_Client_Auth_Call) RunAndReturn(run func(ctx context.Context) erro
 	return _c
 }
 
+// DeleteWord provides a mock function for the type Mock_Client
+func (_mock *Mock_Client) DeleteWord(ctx context.Context, word string) api.OperationResult {
+	ret := _mock.Called(ctx, word)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWord")
+	}
+
+	var r0 api.OperationResult
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) api.OperationResult); ok {
+		r0 = returnFunc(ctx, word)
+	} else {
+		r0 = ret.Get(0).(api.OperationResult)
+	}
+	return r0
+}
+
+// Mock_Client_DeleteWord_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWord'
+type Mock_Client_DeleteWord_Call struct {
+	*mock.Call
+}
+
+// DeleteWord is a helper method to define mock.On call
+//   - ctx context.Context
+//   - word string
+func (_e *Mock_Client_Expecter) DeleteWord(ctx interface{}, word interface{}) *Mock_Client_DeleteWord_Call {
+	return &Mock_Client_DeleteWord_Call{Call: _e.mock.On("DeleteWord", ctx, word)}
+}
+
+func (_c *Mock_Client_DeleteWord_Call) Run(run func(ctx context.Context, word string)) *Mock_Client_DeleteWord_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Mock_Client_DeleteWord_Call) Return(operationResult api.OperationResult) *Mock_Client_DeleteWord_Call {
+	_c.Call.Return(operationResult)
+	return _c
+}
+
+func (_c *Mock_Client_DeleteWord_Call) RunAndReturn(run func(ctx context.Context, word string) api.OperationResult) *Mock_Client_DeleteWord_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDictionary provides a mock function for the type Mock_Client
+func (_mock *Mock_Client) ListDictionary(ctx context.Context, query api.DictionaryQuery) (api.DictionaryPage, error) {
+	ret := _mock.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDictionary")
+	}
+
+	var r0 api.DictionaryPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.DictionaryQuery) (api.DictionaryPage, error)); ok {
+		return returnFunc(ctx, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.DictionaryQuery) api.DictionaryPage); ok {
+		r0 = returnFunc(ctx, query)
+	} else {
+		r0 = ret.Get(0).(api.DictionaryPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, api.DictionaryQuery) error); ok {
+		r1 = returnFunc(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Mock_Client_ListDictionary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDictionary'
+type Mock_Client_ListDictionary_Call struct {
+	*mock.Call
+}
+
+// ListDictionary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query api.DictionaryQuery
+func (_e *Mock_Client_Expecter) ListDictionary(ctx interface{}, query interface{}) *Mock_Client_ListDictionary_Call {
+	return &Mock_Client_ListDictionary_Call{Call: _e.mock.On("ListDictionary", ctx, query)}
+}
+
+func (_c *Mock_Client_ListDictionary_Call) Run(run func(ctx context.Context, query api.DictionaryQuery)) *Mock_Client_ListDictionary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 api.DictionaryQuery
+		if args[1] != nil {
+			arg1 = args[1].(api.DictionaryQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Mock_Client_ListDictionary_Call) Return(dictionaryPage api.DictionaryPage, err error) *Mock_Client_ListDictionary_Call {
+	_c.Call.Return(dictionaryPage, err)
+	return _c
+}
+
+func (_c *Mock_Client_ListDictionary_Call) RunAndReturn(run func(ctx context.Context, query api.DictionaryQuery) (api.DictionaryPage, error)) *Mock_Client_ListDictionary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TranslateWord provides a mock function for the type Mock_Client
 func (_mock *Mock_Client) TranslateWord(ctx context.Context, word string) api.OperationResult {
 	ret := _mock.Called(ctx, word)
@@ -208,3 +331,66 @@ func (_c *Mock_Client_TranslateWord_Call) RunAndReturn(run func(ctx context.Cont
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpdateTranslation provides a mock function for the type Mock_Client
+func (_mock *Mock_Client) UpdateTranslation(ctx context.Context, word string, translates []string) api.OperationResult {
+	ret := _mock.Called(ctx, word, translates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTranslation")
+	}
+
+	var r0 api.OperationResult
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) api.OperationResult); ok {
+		r0 = returnFunc(ctx, word, translates)
+	} else {
+		r0 = ret.Get(0).(api.OperationResult)
+	}
+	return r0
+}
+
+// Mock_Client_UpdateTranslation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTranslation'
+type Mock_Client_UpdateTranslation_Call struct {
+	*mock.Call
+}
+
+// UpdateTranslation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - word string
+//   - translates []string
+func (_e *Mock_Client_Expecter) UpdateTranslation(ctx interface{}, word interface{}, translates interface{}) *Mock_Client_UpdateTranslation_Call {
+	return &Mock_Client_UpdateTranslation_Call{Call: _e.mock.On("UpdateTranslation", ctx, word, translates)}
+}
+
+func (_c *Mock_Client_UpdateTranslation_Call) Run(run func(ctx context.Context, word string, translates []string)) *Mock_Client_UpdateTranslation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Mock_Client_UpdateTranslation_Call) Return(operationResult api.OperationResult) *Mock_Client_UpdateTranslation_Call {
+	_c.Call.Return(operationResult)
+	return _c
+}
+
+func (_c *Mock_Client_UpdateTranslation_Call) RunAndReturn(run func(ctx context.Context, word string, translates []string) api.OperationResult) *Mock_Client_UpdateTranslation_Call {
+	_c.Call.Return(run)
+	return _c
+}
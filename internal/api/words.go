@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+)
+
+const (
+	setWordsOperation  = "actionWithWords"
+	setWordsDelete     = "delete"
+	setWordsUpdate     = "update"
+	setWordsModeDelete = "delete"
+	setWordsModeUpdate = "replace"
+)
+
+var errSetWords = errors.New("cannot change dictionary word")
+
+// setWordsAction is a single change of the user's dictionary sent to the SetWords endpoint.
+type setWordsAction struct {
+	Action       string   `json:"action"`
+	Mode         string   `json:"mode"`
+	Word         string   `json:"wordValue"`
+	Translations []string `json:"translations,omitempty"`
+}
+
+func (a *API) setWordsRequest(ctx context.Context, action setWordsAction) ([]byte, error) {
+	values := map[string]any{
+		"apiVersion": apiVersion,
+		"op":         setWordsOperation,
+		"data":       []setWordsAction{action},
+	}
+	jsonValue, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	return a.request(ctx, requestParams{
+		method: "POST",
+		url:    a.endpoints.SetWords,
+		body:   jsonValue,
+	})
+}
+
+func (a *API) setWords(ctx context.Context, action setWordsAction) OperationResult {
+	res := Result{Word: action.Word, AddWords: action.Translations}
+	body, err := a.authorized(ctx, func() ([]byte, error) {
+		return a.setWordsRequest(ctx, action)
+	})
+	if err != nil {
+		return OperationResult{Error: err, Result: res}
+	}
+	apiErr := apiError{}
+	if err = json.Unmarshal(body, &apiErr); err != nil {
+		return OperationResult{Error: fmt.Errorf("%w: %s, %w", errSetWords, action.Word, err), Result: res}
+	}
+	if len(apiErr.ErrorMsg) > 0 {
+		res.ErrorMsg = apiErr.ErrorMsg
+		return OperationResult{Error: ResultError{Result: res}, Result: res}
+	}
+	return OperationResult{Result: res}
+}
+
+// DeleteWord removes the word with all its translations from the user's dictionary.
+func (a *API) DeleteWord(ctx context.Context, word string) OperationResult {
+	return a.setWords(ctx, setWordsAction{
+		Action: setWordsDelete,
+		Mode:   setWordsModeDelete,
+		Word:   word,
+	})
+}
+
+// UpdateTranslation replaces the translations of a word in the user's dictionary.
+func (a *API) UpdateTranslation(ctx context.Context, word string, translates []string) OperationResult {
+	return a.setWords(ctx, setWordsAction{
+		Action:       setWordsUpdate,
+		Mode:         setWordsModeUpdate,
+		Word:         word,
+		Translations: translates,
+	})
+}
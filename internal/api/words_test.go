@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSetWordsAPI(t *testing.T, response string, request *map[string]any) *API {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.UnmarshalRead(r.Body, request))
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	return &API{
+		client:      server.Client(),
+		endpoints:   Endpoints{SetWords: server.URL},
+		timeout:     time.Second,
+		retryConfig: RetryConfig{MaxAttempts: 1},
+	}
+}
+
+func TestDeleteWord(t *testing.T) {
+	t.Parallel()
+
+	var request map[string]any
+	api := newSetWordsAPI(t, `{"error_msg":""}`, &request)
+
+	res := api.DeleteWord(t.Context(), "hello")
+	require.NoError(t, res.Error)
+	assert.Equal(t, "hello", res.Result.Word)
+
+	assert.Equal(t, setWordsOperation, request["op"])
+	assert.Equal(t, []any{map[string]any{
+		"action":    "delete",
+		"mode":      "delete",
+		"wordValue": "hello",
+	}}, request["data"])
+}
+
+func TestUpdateTranslation(t *testing.T) {
+	t.Parallel()
+
+	var request map[string]any
+	api := newSetWordsAPI(t, `{"error_msg":""}`, &request)
+
+	res := api.UpdateTranslation(t.Context(), "hello", []string{"привет", "алло"})
+	require.NoError(t, res.Error)
+	assert.Equal(t, []string{"привет", "алло"}, res.Result.AddWords)
+
+	assert.Equal(t, []any{map[string]any{
+		"action":       "update",
+		"mode":         "replace",
+		"wordValue":    "hello",
+		"translations": []any{"привет", "алло"},
+	}}, request["data"])
+}
+
+func TestSetWordsReturnsErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	var request map[string]any
+	api := newSetWordsAPI(t, `{"error_msg":"Word is not in the dictionary"}`, &request)
+
+	res := api.DeleteWord(t.Context(), "hello")
+	var resultErr ResultError
+	require.ErrorAs(t, res.Error, &resultErr)
+	assert.Equal(t, "hello: Word is not in the dictionary", res.Error.Error())
+}
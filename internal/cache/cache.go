@@ -0,0 +1,211 @@
+// Package cache provides an api.Client decorator keeping translations on disk.
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/trezorg/lingualeo/internal/api"
+)
+
+const (
+	DefaultTTL        = 7 * 24 * time.Hour
+	DefaultMaxEntries = 10000
+
+	dirName   = "lingualeo"
+	entryDir  = "translations"
+	entryExt  = ".json"
+	dirMode   = 0o700
+	fileMode  = 0o600
+	pruneKeep = 0.9
+)
+
+var (
+	// ErrOfflineMiss is returned in offline mode for words missing from the cache.
+	ErrOfflineMiss = errors.New("word is not in the offline cache")
+	// ErrOffline is returned in offline mode for operations that need the API.
+	ErrOffline = errors.New("operation is not available offline")
+)
+
+// entry is the on-disk representation of a cached translation.
+type entry struct {
+	Stored time.Time  `json:"stored"`
+	Word   string     `json:"word"`
+	Result api.Result `json:"result"`
+}
+
+// Option is a functional option for Client configuration.
+type Option func(*Client)
+
+// WithTTL sets how long a cached translation stays valid.
+func WithTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.store.ttl = ttl
+	}
+}
+
+// WithMaxEntries sets the maximum number of cached translations.
+// The oldest entries are removed when the cache grows over the limit.
+func WithMaxEntries(n int) Option {
+	return func(c *Client) {
+		c.store.maxEntries = n
+	}
+}
+
+// WithNamespace separates cached translations of different namespaces,
+// e.g. language pairs, sharing a directory.
+func WithNamespace(namespace string) Option {
+	return func(c *Client) {
+		c.store.namespace = namespace
+	}
+}
+
+// WithOffline makes the client answer only from the cache.
+func WithOffline(offline bool) Option {
+	return func(c *Client) {
+		c.offline = offline
+	}
+}
+
+// WithClock sets the clock used to check entry expiration.
+func WithClock(now func() time.Time) Option {
+	return func(c *Client) {
+		c.store.now = now
+	}
+}
+
+// Client is an api.Client caching translations in a directory, one file per word.
+// Changes of the dictionary state invalidate the cached translation of the word.
+type Client struct {
+	api.Client
+	store   *store
+	offline bool
+}
+
+// DefaultDir returns the cache directory under the user's cache directory.
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, dirName, entryDir), nil
+}
+
+// New wraps client with a translation cache stored in dir.
+// An empty dir means DefaultDir.
+func New(client api.Client, dir string, opts ...Option) (*Client, error) {
+	c := &Client{
+		Client: client,
+		store: &store{
+			dir:        dir,
+			ttl:        DefaultTTL,
+			maxEntries: DefaultMaxEntries,
+			now:        time.Now,
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if err := c.store.open(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Middleware opens the cache in dir and returns a middleware wrapping clients with it.
+// All clients wrapped by the middleware share the cache.
+func Middleware(dir string, opts ...Option) (api.Middleware, error) {
+	c, err := New(nil, dir, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return func(next api.Client) api.Client {
+		return &Client{Client: next, store: c.store, offline: c.offline}
+	}, nil
+}
+
+// Invalidate removes the cached translation of the word.
+func (c *Client) Invalidate(word string) error {
+	return c.store.invalidate(word)
+}
+
+// TranslateWord returns a cached translation or translates the word and caches the result.
+func (c *Client) TranslateWord(ctx context.Context, word string) api.OperationResult {
+	if result, ok := c.store.get(word); ok {
+		return api.OperationResult{Result: result}
+	}
+	if c.offline {
+		return api.OperationResult{
+			Result: api.Result{Word: word},
+			Error:  fmt.Errorf("%w: %s", ErrOfflineMiss, word),
+		}
+	}
+	res := c.Client.TranslateWord(ctx, word)
+	if res.Error == nil {
+		if err := c.store.put(word, res.Result); err != nil {
+			slog.Warn("cannot cache translation", "word", word, "error", err)
+		}
+	}
+	return res
+}
+
+// AddWord adds the word and drops its cached translation, whose dictionary flags are now stale.
+func (c *Client) AddWord(ctx context.Context, word string, translate string) api.OperationResult {
+	if c.offline {
+		return c.offlineResult(word)
+	}
+	defer c.invalidate(word)
+	return c.Client.AddWord(ctx, word, translate)
+}
+
+// DeleteWord deletes the word and drops its cached translation.
+func (c *Client) DeleteWord(ctx context.Context, word string) api.OperationResult {
+	if c.offline {
+		return c.offlineResult(word)
+	}
+	defer c.invalidate(word)
+	return c.Client.DeleteWord(ctx, word)
+}
+
+// UpdateTranslation updates the word and drops its cached translation.
+func (c *Client) UpdateTranslation(ctx context.Context, word string, translates []string) api.OperationResult {
+	if c.offline {
+		return c.offlineResult(word)
+	}
+	defer c.invalidate(word)
+	return c.Client.UpdateTranslation(ctx, word, translates)
+}
+
+// ListDictionary lists the dictionary. It is not available offline.
+func (c *Client) ListDictionary(ctx context.Context, query api.DictionaryQuery) (api.DictionaryPage, error) {
+	if c.offline {
+		return api.DictionaryPage{}, ErrOffline
+	}
+	return c.Client.ListDictionary(ctx, query)
+}
+
+// Auth authenticates unless the client is offline.
+func (c *Client) Auth(ctx context.Context) error {
+	if c.offline {
+		return nil
+	}
+	return c.Client.Auth(ctx)
+}
+
+func (c *Client) invalidate(word string) {
+	if err := c.store.invalidate(word); err != nil {
+		slog.Warn("cannot invalidate cached translation", "word", word, "error", err)
+	}
+}
+
+func (*Client) offlineResult(word string) api.OperationResult {
+	return api.OperationResult{
+		Result: api.Result{Word: word},
+		Error:  fmt.Errorf("%w: %s", ErrOffline, word),
+	}
+}
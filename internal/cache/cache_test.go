@@ -0,0 +1,177 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trezorg/lingualeo/internal/api"
+	apimock "github.com/trezorg/lingualeo/internal/api/mock"
+)
+
+func translated(word string, values ...string) api.OperationResult {
+	res := api.Result{Word: word, Transcription: "tr"}
+	for _, value := range values {
+		res.Translate = append(res.Translate, api.Word{Value: value, Votes: 1})
+	}
+	return api.OperationResult{Result: res}
+}
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "hello world", Key("  Hello \t World "))
+	require.Equal(t, Key("привет"), Key("ПРИВЕТ"))
+}
+
+func TestTranslateWordIsCached(t *testing.T) {
+	t.Parallel()
+
+	client := apimock.NewMock_Client(t)
+	client.EXPECT().TranslateWord(t.Context(), "Hello").Return(translated("Hello", "привет")).Once()
+
+	c, err := New(client, t.TempDir())
+	require.NoError(t, err)
+
+	res := c.TranslateWord(t.Context(), "Hello")
+	require.NoError(t, res.Error)
+	cached := c.TranslateWord(t.Context(), " hello ")
+	require.NoError(t, cached.Error)
+	require.Equal(t, " hello ", cached.Result.Word)
+	require.Equal(t, res.Result.Translate, cached.Result.Translate)
+	require.Equal(t, "tr", cached.Result.Transcription)
+}
+
+func TestNamespacesDoNotShareTranslations(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	client := apimock.NewMock_Client(t)
+	client.EXPECT().TranslateWord(t.Context(), "hello").Return(translated("hello", "привет")).Once()
+	client.EXPECT().TranslateWord(t.Context(), "hello").Return(translated("hello", "olá")).Once()
+
+	russian, err := New(client, dir, WithNamespace("en-ru"))
+	require.NoError(t, err)
+	portuguese, err := New(client, dir, WithNamespace("en-pt"))
+	require.NoError(t, err)
+
+	require.NoError(t, russian.TranslateWord(t.Context(), "hello").Error)
+	require.NoError(t, portuguese.TranslateWord(t.Context(), "hello").Error)
+	require.Equal(t, "привет", russian.TranslateWord(t.Context(), "hello").Result.Translate[0].Value)
+	require.Equal(t, "olá", portuguese.TranslateWord(t.Context(), "hello").Result.Translate[0].Value)
+}
+
+func TestTranslateWordDoesNotCacheErrors(t *testing.T) {
+	t.Parallel()
+
+	client := apimock.NewMock_Client(t)
+	failed := api.OperationResult{Result: api.Result{Word: "hello"}, Error: os.ErrDeadlineExceeded}
+	client.EXPECT().TranslateWord(t.Context(), "hello").Return(failed).Twice()
+
+	c, err := New(client, t.TempDir())
+	require.NoError(t, err)
+	require.Error(t, c.TranslateWord(t.Context(), "hello").Error)
+	require.Error(t, c.TranslateWord(t.Context(), "hello").Error)
+}
+
+func TestCachedTranslationExpires(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	client := apimock.NewMock_Client(t)
+	client.EXPECT().TranslateWord(t.Context(), "hello").Return(translated("hello", "привет")).Twice()
+
+	c, err := New(client, t.TempDir(), WithTTL(time.Hour), WithClock(func() time.Time { return now }))
+	require.NoError(t, err)
+
+	require.NoError(t, c.TranslateWord(t.Context(), "hello").Error)
+	now = now.Add(59 * time.Minute)
+	require.NoError(t, c.TranslateWord(t.Context(), "hello").Error)
+	now = now.Add(time.Minute)
+	require.NoError(t, c.TranslateWord(t.Context(), "hello").Error)
+}
+
+func TestAddWordInvalidatesTranslation(t *testing.T) {
+	t.Parallel()
+
+	client := apimock.NewMock_Client(t)
+	client.EXPECT().TranslateWord(t.Context(), "hello").Return(translated("hello", "привет")).Twice()
+	client.EXPECT().AddWord(t.Context(), "hello", "привет").Return(translated("hello", "привет")).Once()
+
+	c, err := New(client, t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, c.TranslateWord(t.Context(), "hello").Error)
+	require.NoError(t, c.AddWord(t.Context(), "hello", "привет").Error)
+	require.NoError(t, c.TranslateWord(t.Context(), "hello").Error)
+}
+
+func TestOfflineAnswersFromCacheOnly(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	client := apimock.NewMock_Client(t)
+	client.EXPECT().TranslateWord(t.Context(), "hello").Return(translated("hello", "привет")).Once()
+
+	online, err := New(client, dir)
+	require.NoError(t, err)
+	require.NoError(t, online.TranslateWord(t.Context(), "hello").Error)
+
+	offline, err := New(client, dir, WithOffline(true))
+	require.NoError(t, err)
+	require.NoError(t, offline.Auth(t.Context()))
+
+	res := offline.TranslateWord(t.Context(), "hello")
+	require.NoError(t, res.Error)
+	require.Equal(t, "привет", res.Result.Translate[0].Value)
+
+	require.ErrorIs(t, offline.TranslateWord(t.Context(), "world").Error, ErrOfflineMiss)
+	require.ErrorIs(t, offline.AddWord(t.Context(), "hello", "привет").Error, ErrOffline)
+	_, err = offline.ListDictionary(t.Context(), api.DictionaryQuery{})
+	require.ErrorIs(t, err, ErrOffline)
+}
+
+func TestCacheKeepsMaxEntries(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	now := time.Now()
+	client := apimock.NewMock_Client(t)
+	words := []string{"one", "two", "three", "four", "five"}
+	for _, word := range words {
+		client.EXPECT().TranslateWord(t.Context(), word).Return(translated(word, word)).Once()
+	}
+
+	c, err := New(client, dir, WithMaxEntries(4), WithClock(func() time.Time { return now }))
+	require.NoError(t, err)
+	for _, word := range words {
+		now = now.Add(time.Second)
+		require.NoError(t, c.TranslateWord(t.Context(), word).Error)
+	}
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	_, ok := c.store.get("five")
+	require.True(t, ok)
+	_, ok = c.store.get("one")
+	require.False(t, ok)
+}
+
+func TestMiddlewareSharesCache(t *testing.T) {
+	t.Parallel()
+
+	first := apimock.NewMock_Client(t)
+	first.EXPECT().TranslateWord(t.Context(), "hello").Return(translated("hello", "привет")).Once()
+	second := apimock.NewMock_Client(t)
+
+	middleware, err := Middleware(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, api.Chain(first, middleware).TranslateWord(t.Context(), "hello").Error)
+	res := api.Chain(second, middleware).TranslateWord(t.Context(), "hello")
+	require.NoError(t, res.Error)
+	require.Equal(t, "привет", res.Result.Translate[0].Value)
+}
@@ -0,0 +1,173 @@
+package cache
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trezorg/lingualeo/internal/api"
+)
+
+// store keeps cached translations in a directory, one file per word.
+type store struct {
+	now        func() time.Time
+	dir        string
+	namespace  string
+	ttl        time.Duration
+	maxEntries int
+	entries    int
+	mu         sync.Mutex
+}
+
+// Key normalises a word into the cache key: case and surrounding
+// or repeated whitespace do not matter.
+func Key(word string) string {
+	return strings.ToLower(strings.Join(strings.Fields(word), " "))
+}
+
+// open applies defaults, creates the directory and drops expired entries.
+func (s *store) open() error {
+	s.ttl = cmp.Or(s.ttl, DefaultTTL)
+	s.maxEntries = cmp.Or(s.maxEntries, DefaultMaxEntries)
+	if s.dir == "" {
+		var err error
+		if s.dir, err = DefaultDir(); err != nil {
+			return fmt.Errorf("cache directory: %w", err)
+		}
+	}
+	if err := os.MkdirAll(s.dir, dirMode); err != nil {
+		return err
+	}
+	return s.prune(s.maxEntries)
+}
+
+func (s *store) filename(word string) string {
+	name := Key(word)
+	if s.namespace != "" {
+		name = s.namespace + "\x00" + name
+	}
+	sum := sha256.Sum256([]byte(name))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+entryExt)
+}
+
+func (s *store) expired(e entry) bool {
+	return !e.Stored.Add(s.ttl).After(s.now())
+}
+
+func (s *store) get(word string) (api.Result, bool) {
+	data, err := os.ReadFile(s.filename(word))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			slog.Warn("cannot read cached translation", "word", word, "error", err)
+		}
+		return api.Result{}, false
+	}
+	var e entry
+	if err = json.Unmarshal(data, &e); err != nil {
+		slog.Warn("cannot decode cached translation", "word", word, "error", err)
+		return api.Result{}, false
+	}
+	if s.expired(e) || Key(e.Word) != Key(word) {
+		return api.Result{}, false
+	}
+	e.Result.Word = word
+	return e.Result, true
+}
+
+func (s *store) put(word string, result api.Result) error {
+	stored := s.now()
+	data, err := json.Marshal(entry{Stored: stored, Word: Key(word), Result: result})
+	if err != nil {
+		return err
+	}
+	filename := s.filename(word)
+	_, statErr := os.Stat(filename)
+	if err = os.WriteFile(filename, data, fileMode); err != nil {
+		return err
+	}
+	// Pruning relies on the modification time being the time the entry was stored.
+	if err = os.Chtimes(filename, stored, stored); err != nil {
+		return err
+	}
+	if statErr == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries++
+	if s.entries <= s.maxEntries {
+		return nil
+	}
+	return s.pruneLocked(int(float64(s.maxEntries) * pruneKeep))
+}
+
+func (s *store) invalidate(word string) error {
+	err := os.Remove(s.filename(word))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = max(s.entries-1, 0)
+	return nil
+}
+
+func (s *store) prune(keep int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pruneLocked(keep)
+}
+
+// pruneLocked removes expired entries and the oldest entries over keep.
+// It must be called with s.mu held.
+func (s *store) pruneLocked(keep int) error {
+	dirEntries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	type cached struct {
+		modified time.Time
+		name     string
+	}
+	files := make([]cached, 0, len(dirEntries))
+	expiredBefore := s.now().Add(-s.ttl)
+	for _, de := range dirEntries {
+		if de.IsDir() || filepath.Ext(de.Name()) != entryExt {
+			continue
+		}
+		info, iErr := de.Info()
+		if iErr != nil {
+			continue
+		}
+		if !info.ModTime().After(expiredBefore) {
+			_ = os.Remove(filepath.Join(s.dir, de.Name()))
+			continue
+		}
+		files = append(files, cached{modified: info.ModTime(), name: de.Name()})
+	}
+	slices.SortFunc(files, func(a, b cached) int {
+		return b.modified.Compare(a.modified)
+	})
+	for _, f := range files[min(keep, len(files)):] {
+		if rErr := os.Remove(filepath.Join(s.dir, f.name)); rErr != nil && !errors.Is(rErr, fs.ErrNotExist) {
+			return rErr
+		}
+	}
+	s.entries = min(keep, len(files))
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"sync"
 	"time"
+
+	"github.com/trezorg/lingualeo/internal/heap"
 )
 
 func ToChannel[T any](ctx context.Context, input ...T) <-chan T {
@@ -146,3 +148,59 @@ func BatchWithTimeout[T any](ctx context.Context, input <-chan T, maxItems int,
 	}()
 	return out
 }
+
+// Indexed is a value with its position in the input, see Ordered.
+type Indexed[T any] struct {
+	Value T
+	Index int
+}
+
+// GetIndex returns the position of the value
+func (i Indexed[T]) GetIndex() int {
+	return i.Index
+}
+
+// Ordered sends the items of input ordered by GetIndex. Indexes must start
+// at zero without gaps: items after a missing index are held back until
+// input is closed and then sent in order. Size is the output buffer.
+func Ordered[T heap.IndexedItem](ctx context.Context, input <-chan T, size int) <-chan T {
+	out := make(chan T, size)
+	go func() {
+		defer close(out)
+		slideIndex := 0
+		items := heap.NewIndexedHeap()
+		check := func(item *heap.IndexedItem) bool {
+			return item != nil && (*item).GetIndex() == slideIndex
+		}
+		send := func(item *heap.IndexedItem) bool {
+			value, ok := (*item).(T)
+			if !ok {
+				return true
+			}
+			select {
+			case out <- value:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		for v := range OrDone(ctx, input) {
+			items.Add(v)
+			for item := items.PullWithCondition(check); item != nil; item = items.PullWithCondition(check) {
+				slideIndex++
+				if !send(item) {
+					return
+				}
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		for item := items.Pull(); item != nil; item = items.Pull() {
+			if !send(item) {
+				return
+			}
+		}
+	}()
+	return out
+}
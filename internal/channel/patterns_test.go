@@ -290,3 +290,40 @@ func TestBatchWithTimeout(t *testing.T) {
 		assert.Equal(t, []int{3}, batches[1])
 	})
 }
+
+func TestOrdered(t *testing.T) {
+	indexed := func(values ...int) chan Indexed[string] {
+		input := make(chan Indexed[string], len(values))
+		for _, index := range values {
+			input <- Indexed[string]{Value: string(rune('a' + index)), Index: index}
+		}
+		close(input)
+		return input
+	}
+	values := func(ch <-chan Indexed[string]) []string {
+		var out []string
+		for item := range ch {
+			out = append(out, item.Value)
+		}
+		return out
+	}
+
+	t.Run("orders by index", func(t *testing.T) {
+		ch := Ordered(t.Context(), indexed(2, 0, 3, 1), 0)
+		assert.Equal(t, []string{"a", "b", "c", "d"}, values(ch))
+	})
+
+	t.Run("items after a missing index are sent on close", func(t *testing.T) {
+		ch := Ordered(t.Context(), indexed(3, 0, 2), 0)
+		assert.Equal(t, []string{"a", "c", "d"}, values(ch))
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancelCause(t.Context())
+		input := make(chan Indexed[string])
+		ch := Ordered(ctx, input, 0)
+		cancel(context.Canceled)
+		_, ok := <-ch
+		assert.False(t, ok, "channel should be closed after context cancellation")
+	})
+}
@@ -0,0 +1,15 @@
+// Package command parses external commands configured by the user, e.g. the player or the pager.
+package command
+
+import "github.com/google/shlex"
+
+// Parse splits a command line into the executable and its arguments, honouring shell quoting.
+// The executable is empty when the command is empty or cannot be split.
+func Parse(command string) (string, []string) {
+	parts, err := shlex.Split(command)
+	if err != nil || len(parts) == 0 {
+		return "", []string{}
+	}
+
+	return parts[0], parts[1:]
+}
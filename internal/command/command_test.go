@@ -0,0 +1,28 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		command string
+		name    string
+		args    []string
+	}{
+		{command: "less -FRX", name: "less", args: []string{"-FRX"}},
+		{command: "mpg123", name: "mpg123", args: []string{}},
+		{command: `sed 's/^/  /'`, name: "sed", args: []string{"s/^/  /"}},
+		{command: "", name: "", args: []string{}},
+		{command: `less "unterminated`, name: "", args: []string{}},
+	}
+	for _, tt := range tests {
+		name, args := Parse(tt.command)
+		require.Equal(t, tt.name, name, tt.command)
+		require.Equal(t, tt.args, args, tt.command)
+	}
+}
@@ -0,0 +1,634 @@
+package fakeapi
+
+import (
+	"cmp"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json/v2"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Endpoint paths served by the fake server. They match the real API,
+// so the server can be used with api.EndpointsFromBaseURL.
+const (
+	AuthPath      = "/api/auth"
+	TranslatePath = "/getTranslates"
+	AddWordPath   = "/addWord"
+	GetWordsPath  = "/GetWords"
+	SetWordsPath  = "/SetWords"
+
+	// FaultsPath accepts a JSON encoded Fault to inject at runtime.
+	FaultsPath = "/_fake/faults"
+	// ExpirePath drops all sessions, as if they had expired on the server.
+	ExpirePath = "/_fake/expire"
+)
+
+const (
+	DefaultEmail    = "user@example.com"
+	DefaultPassword = "password"
+
+	sessionCookie     = "remember"
+	sessionTokenBytes = 16
+	errorCodeAuth     = 403
+	errorCodeNoAuth   = 401
+	defaultPerPage    = 100
+	statusNew         = "new"
+	setWordsDelete    = "delete"
+	setWordsUpdate    = "update"
+	day               = 24 * time.Hour
+)
+
+// Translation is a single translation of an Entry.
+type Translation struct {
+	Value   string
+	Picture string
+	ID      int
+	Votes   int
+}
+
+// WordForm is an inflected form of an Entry.
+type WordForm struct {
+	Word string
+	Type string
+}
+
+// Entry is a word known to the fake server.
+type Entry struct {
+	Word          string
+	Transcription string
+	SoundURL      string
+	PictureURL    string
+	Forms         []WordForm
+	Translations  []Translation
+	WordID        int
+}
+
+// DictionaryWord is a word in the user's dictionary on the fake server.
+type DictionaryWord struct {
+	Added        time.Time
+	Word         string
+	Status       string
+	Translations []string
+}
+
+// Fault describes a failure returned instead of handling a request.
+type Fault struct {
+	// Path is the endpoint to fail. Empty path matches every endpoint.
+	Path string `json:"path"`
+	// ErrorMsg is returned as error_msg in a JSON body.
+	ErrorMsg string `json:"error_msg"`
+	// Status is the HTTP status code, http.StatusOK when empty.
+	Status int `json:"status"`
+	// ErrorCode is returned as error_code in a JSON body.
+	ErrorCode int `json:"error_code"`
+	// Headers are set on the response, e.g. Retry-After for a 429 status.
+	Headers map[string]string `json:"headers"`
+	// Times is the number of requests to fail, one when empty.
+	Times int `json:"times"`
+}
+
+// Option configures Server.
+type Option func(*Server)
+
+// WithCredentials sets the only email and password accepted by the auth endpoint.
+func WithCredentials(email string, password string) Option {
+	return func(s *Server) {
+		s.email = email
+		s.password = password
+	}
+}
+
+// WithEntries adds words the server can translate.
+func WithEntries(entries ...Entry) Option {
+	return func(s *Server) {
+		for _, entry := range entries {
+			s.entries[normalize(entry.Word)] = entry
+		}
+	}
+}
+
+// WithDictionary seeds the user's dictionary.
+func WithDictionary(words ...DictionaryWord) Option {
+	return func(s *Server) {
+		for _, word := range words {
+			word.Status = cmp.Or(word.Status, statusNew)
+			s.dictionary[normalize(word.Word)] = &word
+		}
+	}
+}
+
+// WithClock sets the clock used to stamp added words.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// Server is an in-memory stand-in for the Lingualeo API.
+// It implements http.Handler and can be wrapped with httptest.NewServer.
+type Server struct {
+	entries    map[string]Entry
+	dictionary map[string]*DictionaryWord
+	sessions   map[string]struct{}
+	requests   map[string]int
+	now        func() time.Time
+	email      string
+	password   string
+	faults     []Fault
+	mu         sync.Mutex
+}
+
+// NewServer creates a fake server seeded with DefaultEntries.
+func NewServer(options ...Option) *Server {
+	s := &Server{
+		entries:    make(map[string]Entry),
+		dictionary: make(map[string]*DictionaryWord),
+		sessions:   make(map[string]struct{}),
+		requests:   make(map[string]int),
+		now:        time.Now,
+		email:      DefaultEmail,
+		password:   DefaultPassword,
+	}
+	WithEntries(DefaultEntries()...)(s)
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+// DefaultEntries returns the words the server knows out of the box.
+// The first one matches ResponseData.
+func DefaultEntries() []Entry {
+	return []Entry{
+		{
+			Word:          SearchWord,
+			Transcription: "əkəədˈeɪːʃən",
+			SoundURL:      SoundURL,
+			PictureURL:    "https://contentcdn.lingualeo.com/uploads/picture/3589594.png",
+			WordID:        102085,
+			Forms:         []WordForm{{Word: "accommodation", Type: "прил."}},
+			Translations: []Translation{
+				{ID: 2569250, Value: "жильё", Votes: 5703, Picture: PictureUrls[0].String()},
+				{ID: 2718711, Value: "проживание", Votes: 1589, Picture: PictureUrls[1].String()},
+				{ID: 185932, Value: "размещение", Votes: 880, Picture: PictureUrls[2].String()},
+				{ID: 2735899, Value: "помещение", Votes: 268, Picture: PictureUrls[3].String()},
+			},
+		},
+		{
+			Word:          "hello",
+			Transcription: "həˈləʊ",
+			SoundURL:      "https://audiocdn.lingualeo.com/v2/3/34365-631152000.mp3",
+			WordID:        34365,
+			Forms:         []WordForm{{Word: "hellos", Type: "мн.ч."}},
+			Translations: []Translation{
+				{ID: 1107, Value: "привет", Votes: 9241},
+				{ID: 1108, Value: "здравствуйте", Votes: 3122},
+			},
+		},
+		{
+			Word:   "привет",
+			WordID: 512301,
+			Translations: []Translation{
+				{ID: 3001, Value: "hello", Votes: 4100},
+				{ID: 3002, Value: "hi", Votes: 2120},
+			},
+		},
+	}
+}
+
+// Inject makes the server fail the next Fault.Times requests to Fault.Path.
+func (s *Server) Inject(f Fault) {
+	if f.Times <= 0 {
+		f.Times = 1
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, f)
+}
+
+// FailAuth rejects the next times authentication attempts.
+func (s *Server) FailAuth(times int) {
+	s.Inject(Fault{Path: AuthPath, ErrorMsg: "Incorrect email or password", ErrorCode: errorCodeAuth, Times: times})
+}
+
+// ExpireSessions forgets all sessions, so clients have to authenticate again.
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.sessions)
+}
+
+// Requests returns the number of requests received by the endpoint path.
+func (s *Server) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[path]
+}
+
+// Dictionary returns the translations the user added for the word.
+func (s *Server) Dictionary(word string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entry, ok := s.dictionary[normalize(word)]; ok {
+		return slices.Clone(entry.Translations)
+	}
+	return nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case FaultsPath:
+		s.handleFaults(w, r)
+		return
+	case ExpirePath:
+		s.ExpireSessions()
+		return
+	}
+
+	s.mu.Lock()
+	s.requests[r.URL.Path]++
+	fault, failed := s.takeFault(r.URL.Path)
+	s.mu.Unlock()
+
+	if failed {
+		writeFault(w, fault)
+		return
+	}
+
+	switch r.URL.Path {
+	case AuthPath:
+		s.handleAuth(w, r)
+	case TranslatePath:
+		s.handleTranslate(w, r)
+	case AddWordPath:
+		s.handleAddWord(w, r)
+	case GetWordsPath:
+		s.handleGetWords(w, r)
+	case SetWordsPath:
+		s.handleSetWords(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// takeFault must be called with s.mu held.
+func (s *Server) takeFault(path string) (Fault, bool) {
+	for i, f := range s.faults {
+		if f.Path != "" && f.Path != path {
+			continue
+		}
+		f.Times--
+		if f.Times <= 0 {
+			s.faults = slices.Delete(s.faults, i, i+1)
+		} else {
+			s.faults[i] = f
+		}
+		return f, true
+	}
+	return Fault{}, false
+}
+
+func writeFault(w http.ResponseWriter, f Fault) {
+	for name, value := range f.Headers {
+		w.Header().Set(name, value)
+	}
+	status := f.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	if f.ErrorMsg == "" && f.ErrorCode == 0 {
+		w.WriteHeader(status)
+		return
+	}
+	writeJSON(w, status, errorResponse{ErrorMsg: f.ErrorMsg, ErrorCode: f.ErrorCode})
+}
+
+func (s *Server) handleAuth(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.Email != s.email || req.Password != s.password {
+		writeJSON(w, http.StatusOK, errorResponse{ErrorMsg: "Incorrect email or password", ErrorCode: errorCodeAuth})
+		return
+	}
+	token := newToken()
+	s.mu.Lock()
+	s.sessions[token] = struct{}{}
+	s.mu.Unlock()
+	http.SetCookie(w, &http.Cookie{Name: sessionCookie, Value: token, Path: "/", HttpOnly: true})
+	writeJSON(w, http.StatusOK, errorResponse{})
+}
+
+func (s *Server) handleTranslate(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Text string `json:"text"`
+	}
+	if !s.authorized(w, r) || !decodeRequest(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.translation(req.Text))
+}
+
+func (s *Server) handleAddWord(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Word  string `json:"word"`
+		Tword string `json:"tword"`
+	}
+	if !s.authorized(w, r) || !decodeRequest(w, r, &req) {
+		return
+	}
+	if strings.TrimSpace(req.Word) == "" || strings.TrimSpace(req.Tword) == "" {
+		writeJSON(w, http.StatusOK, errorResponse{ErrorMsg: "word and translation are required"})
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// The response reflects the dictionary state before the word was added.
+	res := s.translation(req.Word)
+	key := normalize(req.Word)
+	entry, ok := s.dictionary[key]
+	if !ok {
+		entry = &DictionaryWord{Word: req.Word, Status: statusNew, Added: s.now()}
+		s.dictionary[key] = entry
+	}
+	if !slices.Contains(entry.Translations, req.Tword) {
+		entry.Translations = append(entry.Translations, req.Tword)
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (s *Server) handleGetWords(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Status   string `json:"status"`
+		DateFrom string `json:"dateFrom"`
+		DateTo   string `json:"dateTo"`
+		Page     int    `json:"page"`
+		PerPage  int    `json:"perPage"`
+	}
+	if !s.authorized(w, r) || !decodeRequest(w, r, &req) {
+		return
+	}
+	from, fromErr := parseDate(req.DateFrom)
+	to, toErr := parseDate(req.DateTo)
+	if err := errors.Join(fromErr, toErr); err != nil {
+		writeJSON(w, http.StatusOK, errorResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	words := make([]*DictionaryWord, 0, len(s.dictionary))
+	for _, word := range s.dictionary {
+		added := word.Added.UTC().Truncate(day)
+		switch {
+		case req.Status != "" && req.Status != word.Status:
+		case !from.IsZero() && added.Before(from):
+		case !to.IsZero() && added.After(to):
+		default:
+			words = append(words, word)
+		}
+	}
+	slices.SortFunc(words, func(a, b *DictionaryWord) int {
+		return cmp.Or(b.Added.Compare(a.Added), cmp.Compare(a.Word, b.Word))
+	})
+
+	res := getWordsResponse{Total: len(words), Data: []dictionaryWordResponse{}}
+	perPage := cmp.Or(req.PerPage, defaultPerPage)
+	start := min(max(req.Page-1, 0)*perPage, len(words))
+	for _, word := range words[start:min(start+perPage, len(words))] {
+		res.Data = append(res.Data, s.dictionaryWord(word))
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (s *Server) handleSetWords(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Data []struct {
+			Action       string   `json:"action"`
+			Word         string   `json:"wordValue"`
+			Translations []string `json:"translations"`
+		} `json:"data"`
+	}
+	if !s.authorized(w, r) || !decodeRequest(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, action := range req.Data {
+		key := normalize(action.Word)
+		entry, ok := s.dictionary[key]
+		if !ok {
+			writeJSON(w, http.StatusOK, errorResponse{ErrorMsg: "Word is not in the dictionary"})
+			return
+		}
+		switch action.Action {
+		case setWordsDelete:
+			delete(s.dictionary, key)
+		case setWordsUpdate:
+			if len(action.Translations) == 0 {
+				writeJSON(w, http.StatusOK, errorResponse{ErrorMsg: "translations are required"})
+				return
+			}
+			entry.Translations = slices.Clone(action.Translations)
+		default:
+			writeJSON(w, http.StatusOK, errorResponse{ErrorMsg: "unknown action: " + action.Action})
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, errorResponse{})
+}
+
+// dictionaryWord must be called with s.mu held.
+func (s *Server) dictionaryWord(word *DictionaryWord) dictionaryWordResponse {
+	entry := s.entries[normalize(word.Word)]
+	res := dictionaryWordResponse{
+		ID:             entry.WordID,
+		WordValue:      word.Word,
+		Transcription:  entry.Transcription,
+		SoundURL:       entry.SoundURL,
+		LearningStatus: word.Status,
+		Created:        word.Added.Unix(),
+		Translations:   make([]dictionaryTranslation, 0, len(word.Translations)),
+	}
+	for _, value := range word.Translations {
+		tr := dictionaryTranslation{Value: value}
+		for _, known := range entry.Translations {
+			if known.Value == value {
+				tr.ID = known.ID
+				tr.Picture = known.Picture
+			}
+		}
+		res.Translations = append(res.Translations, tr)
+	}
+	return res
+}
+
+func parseDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.DateOnly, s)
+}
+
+func (s *Server) handleFaults(w http.ResponseWriter, r *http.Request) {
+	var f Fault
+	if !decodeRequest(w, r, &f) {
+		return
+	}
+	s.Inject(f)
+}
+
+func (s *Server) authorized(w http.ResponseWriter, r *http.Request) bool {
+	if cookie, err := r.Cookie(sessionCookie); err == nil {
+		s.mu.Lock()
+		_, ok := s.sessions[cookie.Value]
+		s.mu.Unlock()
+		if ok {
+			return true
+		}
+	}
+	writeJSON(w, http.StatusUnauthorized, errorResponse{ErrorMsg: "Unauthorized", ErrorCode: errorCodeNoAuth})
+	return false
+}
+
+// translation must be called with s.mu held.
+func (s *Server) translation(word string) translateResponse {
+	key := normalize(word)
+	entry, ok := s.entries[key]
+	var added []string
+	if dict, found := s.dictionary[key]; found {
+		added = dict.Translations
+	}
+	res := translateResponse{
+		TranslateSource: "base",
+		Transcription:   entry.Transcription,
+		SoundURL:        entry.SoundURL,
+		PictureURL:      entry.PictureURL,
+		WordID:          entry.WordID,
+		IsUser:          boolToInt(len(added) > 0),
+		WordForms:       []wordFormResponse{},
+		Translate:       []translateItem{},
+	}
+	if !ok {
+		res.TranslateSource = ""
+	}
+	for _, form := range entry.Forms {
+		res.WordForms = append(res.WordForms, wordFormResponse(form))
+	}
+	for _, tr := range entry.Translations {
+		res.Translate = append(res.Translate, translateItem{
+			ID:      tr.ID,
+			Value:   tr.Value,
+			Votes:   tr.Votes,
+			Picture: tr.Picture,
+			UT:      boolToInt(slices.Contains(added, tr.Value)),
+		})
+	}
+	for _, value := range added {
+		if slices.ContainsFunc(entry.Translations, func(tr Translation) bool { return tr.Value == value }) {
+			continue
+		}
+		res.Translate = append(res.Translate, translateItem{Value: value, UT: 1})
+	}
+	return res
+}
+
+type dictionaryTranslation struct {
+	Value   string `json:"tr"`
+	Picture string `json:"pic_url"`
+	ID      int    `json:"id"`
+}
+
+type dictionaryWordResponse struct {
+	WordValue      string                  `json:"wordValue"`
+	Transcription  string                  `json:"transcription"`
+	SoundURL       string                  `json:"sound_url"`
+	LearningStatus string                  `json:"learningStatus"`
+	Translations   []dictionaryTranslation `json:"translations"`
+	Created        int64                   `json:"created"`
+	ID             int                     `json:"id"`
+}
+
+type getWordsResponse struct {
+	ErrorMsg string                   `json:"error_msg"`
+	Data     []dictionaryWordResponse `json:"data"`
+	Total    int                      `json:"total"`
+}
+
+type errorResponse struct {
+	ErrorMsg  string `json:"error_msg"`
+	ErrorCode int    `json:"error_code"`
+}
+
+type wordFormResponse struct {
+	Word string `json:"word"`
+	Type string `json:"type"`
+}
+
+type translateItem struct {
+	Value   string `json:"value"`
+	Picture string `json:"pic_url"`
+	ID      int    `json:"id"`
+	Votes   int    `json:"votes"`
+	UT      int    `json:"ut"`
+}
+
+type translateResponse struct {
+	ErrorMsg        string             `json:"error_msg"`
+	TranslateSource string             `json:"translate_source"`
+	Transcription   string             `json:"transcription"`
+	SoundURL        string             `json:"sound_url"`
+	PictureURL      string             `json:"pic_url"`
+	WordForms       []wordFormResponse `json:"word_forms"`
+	Translate       []translateItem    `json:"translate"`
+	IsUser          int                `json:"is_user"`
+	WordID          int                `json:"word_id"`
+	WordTop         int                `json:"word_top"`
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request, v any) bool {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return false
+	}
+	if err := json.UnmarshalRead(r.Body, v); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{ErrorMsg: err.Error()})
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.MarshalWrite(w, v)
+}
+
+func newToken() string {
+	b := make([]byte, sessionTokenBytes)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func normalize(word string) string {
+	return strings.ToLower(strings.TrimSpace(word))
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,281 @@
+package fakeapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/httpclient"
+)
+
+func newClient(t *testing.T, server *httptest.Server, password string) *api.API {
+	t.Helper()
+
+	httpClient, err := httpclient.NewWithJar(httpclient.Config{}, 0)
+	require.NoError(t, err)
+	cfg := api.DefaultConfig()
+	cfg.Endpoints = api.EndpointsFromBaseURL(server.URL)
+	cfg.Retry = api.RetryConfig{MaxAttempts: 3, InitialWait: time.Millisecond, MaxWait: time.Millisecond}
+
+	return api.New(DefaultEmail, password, false, cfg, httpClient)
+}
+
+func TestServerTranslateAndAddWord(t *testing.T) {
+	t.Parallel()
+
+	fake := NewServer()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	client := newClient(t, server, DefaultPassword)
+
+	require.NoError(t, client.Auth(t.Context()))
+
+	res := client.TranslateWord(t.Context(), SearchWord)
+	require.NoError(t, res.Error)
+	CheckResult(t, res.Result, SearchWord, Expected)
+	require.False(t, res.Result.InDictionary())
+
+	added := client.AddWord(t.Context(), SearchWord, "жильё")
+	require.NoError(t, added.Error)
+	require.False(t, added.Result.InDictionary())
+	require.Equal(t, []string{"жильё"}, fake.Dictionary(SearchWord))
+
+	res = client.TranslateWord(t.Context(), SearchWord)
+	require.NoError(t, res.Error)
+	require.True(t, res.Result.InDictionary())
+	require.True(t, bool(res.Result.Exists))
+}
+
+func TestServerAddsCustomTranslation(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(NewServer())
+	defer server.Close()
+	client := newClient(t, server, DefaultPassword)
+	require.NoError(t, client.Auth(t.Context()))
+
+	require.NoError(t, client.AddWord(t.Context(), "hello", "алло").Error)
+
+	res := client.TranslateWord(t.Context(), "hello")
+	require.NoError(t, res.Error)
+	values := make([]string, 0, len(res.Result.Translate))
+	for _, tr := range res.Result.Translate {
+		values = append(values, tr.Value)
+	}
+	require.Contains(t, values, "алло")
+}
+
+func TestServerDeletesAndEditsWords(t *testing.T) {
+	t.Parallel()
+
+	fake := NewServer()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	client := newClient(t, server, DefaultPassword)
+	require.NoError(t, client.Auth(t.Context()))
+	require.NoError(t, client.AddWord(t.Context(), SearchWord, "жильё").Error)
+
+	updated := client.UpdateTranslation(t.Context(), SearchWord, []string{"размещение", "помещение"})
+	require.NoError(t, updated.Error)
+	require.Equal(t, []string{"размещение", "помещение"}, fake.Dictionary(SearchWord))
+
+	require.NoError(t, client.DeleteWord(t.Context(), SearchWord).Error)
+	require.Empty(t, fake.Dictionary(SearchWord))
+	res := client.TranslateWord(t.Context(), SearchWord)
+	require.NoError(t, res.Error)
+	require.False(t, res.Result.InDictionary())
+
+	missing := client.DeleteWord(t.Context(), SearchWord)
+	var resultErr api.ResultError
+	require.ErrorAs(t, missing.Error, &resultErr)
+	require.Equal(t, SearchWord, resultErr.Result.Word)
+}
+
+func TestServerUnknownWordHasNoTranslations(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(NewServer())
+	defer server.Close()
+	client := newClient(t, server, DefaultPassword)
+
+	res := client.TranslateWord(t.Context(), "qwertyuiop")
+	require.NoError(t, res.Error)
+	require.Empty(t, res.Result.Translate)
+}
+
+func TestServerRejectsWrongPassword(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(NewServer())
+	defer server.Close()
+
+	require.Error(t, newClient(t, server, "wrong").Auth(t.Context()))
+}
+
+func TestServerReauthenticatesExpiredSession(t *testing.T) {
+	t.Parallel()
+
+	fake := NewServer()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	client := newClient(t, server, DefaultPassword)
+	require.NoError(t, client.Auth(t.Context()))
+
+	fake.ExpireSessions()
+
+	require.NoError(t, client.TranslateWord(t.Context(), "hello").Error)
+	require.Equal(t, 2, fake.Requests(AuthPath))
+}
+
+func TestServerInjectedFaults(t *testing.T) {
+	t.Parallel()
+
+	t.Run("auth failure", func(t *testing.T) {
+		t.Parallel()
+
+		fake := NewServer()
+		fake.FailAuth(1)
+		server := httptest.NewServer(fake)
+		defer server.Close()
+		client := newClient(t, server, DefaultPassword)
+
+		require.Error(t, client.Auth(t.Context()))
+		require.NoError(t, client.Auth(t.Context()))
+	})
+
+	t.Run("error message", func(t *testing.T) {
+		t.Parallel()
+
+		fake := NewServer()
+		fake.Inject(Fault{Path: TranslatePath, ErrorMsg: "Word is too long"})
+		server := httptest.NewServer(fake)
+		defer server.Close()
+		client := newClient(t, server, DefaultPassword)
+
+		res := client.TranslateWord(t.Context(), "hello")
+		var resultErr api.ResultError
+		require.True(t, errors.As(res.Error, &resultErr))
+		require.Contains(t, res.Error.Error(), "Word is too long")
+	})
+
+	for _, status := range []int{http.StatusInternalServerError, http.StatusTooManyRequests} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			t.Parallel()
+
+			fake := NewServer()
+			fake.Inject(Fault{Path: TranslatePath, Status: status, Times: 2})
+			server := httptest.NewServer(fake)
+			defer server.Close()
+			client := newClient(t, server, DefaultPassword)
+
+			require.NoError(t, client.TranslateWord(t.Context(), "hello").Error)
+			require.Equal(t, 4, fake.Requests(TranslatePath))
+		})
+	}
+}
+
+func TestServerFaultsWithRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("waited for", func(t *testing.T) {
+		t.Parallel()
+
+		fake := NewServer()
+		fake.Inject(Fault{Path: TranslatePath, Status: http.StatusTooManyRequests, Headers: map[string]string{"Retry-After": "1"}})
+		server := httptest.NewServer(fake)
+		defer server.Close()
+		client := newClient(t, server, DefaultPassword)
+		require.NoError(t, client.Auth(t.Context()))
+
+		started := time.Now()
+		require.NoError(t, client.TranslateWord(t.Context(), "hello").Error)
+		require.GreaterOrEqual(t, time.Since(started), time.Second)
+		require.Equal(t, 2, fake.Requests(TranslatePath))
+	})
+
+	t.Run("longer than allowed", func(t *testing.T) {
+		t.Parallel()
+
+		fake := NewServer()
+		fake.Inject(Fault{Path: TranslatePath, Status: http.StatusTooManyRequests, Headers: map[string]string{"Retry-After": "3600"}})
+		server := httptest.NewServer(fake)
+		defer server.Close()
+		client := newClient(t, server, DefaultPassword)
+		require.NoError(t, client.Auth(t.Context()))
+
+		res := client.TranslateWord(t.Context(), "hello")
+		require.ErrorContains(t, res.Error, "retry after 1h0m0s")
+		require.Equal(t, 1, fake.Requests(TranslatePath))
+	})
+}
+
+func TestServerFaultsEndpoint(t *testing.T) {
+	t.Parallel()
+
+	fake := NewServer()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, server.URL+FaultsPath,
+		strings.NewReader(`{"path":"/addWord","status":503,"times":5}`))
+	require.NoError(t, err)
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	client := newClient(t, server, DefaultPassword)
+	require.NoError(t, client.Auth(t.Context()))
+	require.Error(t, client.AddWord(t.Context(), "hello", "привет").Error)
+}
+
+func TestServerListsDictionary(t *testing.T) {
+	t.Parallel()
+
+	jan := func(day int) time.Time { return time.Date(2024, time.January, day, 12, 0, 0, 0, time.UTC) }
+	fake := NewServer(
+		WithClock(func() time.Time { return jan(5) }),
+		WithDictionary(
+			DictionaryWord{Word: "hello", Status: "learned", Added: jan(1), Translations: []string{"привет"}},
+			DictionaryWord{Word: "world", Status: "learning", Added: jan(2), Translations: []string{"мир"}},
+		),
+	)
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	client := newClient(t, server, DefaultPassword)
+	require.NoError(t, client.Auth(t.Context()))
+	require.NoError(t, client.AddWord(t.Context(), SearchWord, "жильё").Error)
+
+	page, err := client.ListDictionary(t.Context(), api.DictionaryQuery{PerPage: 2})
+	require.NoError(t, err)
+	require.Equal(t, 3, page.Total)
+	require.True(t, page.HasMore())
+	require.Equal(t, []string{SearchWord, "world"}, dictionaryWords(page))
+	require.Equal(t, api.StatusNew, page.Words[0].Status)
+
+	page, err = client.ListDictionary(t.Context(), page.NextQuery(api.DictionaryQuery{}))
+	require.NoError(t, err)
+	require.Equal(t, []string{"hello"}, dictionaryWords(page))
+	require.False(t, page.HasMore())
+
+	page, err = client.ListDictionary(t.Context(), api.DictionaryQuery{Status: api.StatusLearning})
+	require.NoError(t, err)
+	require.Equal(t, []string{"world"}, dictionaryWords(page))
+
+	page, err = client.ListDictionary(t.Context(), api.DictionaryQuery{AddedAfter: jan(2), AddedBefore: jan(4)})
+	require.NoError(t, err)
+	require.Equal(t, []string{"world"}, dictionaryWords(page))
+}
+
+func dictionaryWords(page api.DictionaryPage) []string {
+	words := make([]string, 0, len(page.Words))
+	for _, word := range page.Words {
+		words = append(words, word.Word)
+	}
+	return words
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 
+	"github.com/trezorg/lingualeo/internal/httpclient"
 	"github.com/trezorg/lingualeo/internal/validator"
 )
 
@@ -17,6 +18,7 @@ const fileTemplate = "lingualeo"
 type File struct {
 	Error    error
 	Filename string
+	URL      string
 	Index    int
 }
 
@@ -27,14 +29,45 @@ func (f File) GetIndex() int {
 
 // FileDownloader structure
 type FileDownloader struct {
-	client *http.Client
+	client   *http.Client
+	redactor *httpclient.Redactor
+}
+
+// Option configures FileDownloader.
+type Option func(*FileDownloader)
+
+// WithDebug logs requests and response headers redacted by the redactor.
+// Downloaded content is never dumped.
+func WithDebug(redactor *httpclient.Redactor) Option {
+	return func(f *FileDownloader) {
+		f.redactor = redactor
+	}
 }
 
 // New creates a new file downloader with the provided HTTP client.
-func New(client *http.Client) *FileDownloader {
-	return &FileDownloader{
+func New(client *http.Client, opts ...Option) *FileDownloader {
+	f := &FileDownloader{
 		client: client,
 	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// do sends the request, dumping it when debugging is enabled.
+func (f *FileDownloader) do(req *http.Request) (*http.Response, error) {
+	if f.redactor != nil {
+		f.redactor.LogRequest(req, false)
+	}
+	resp, err := f.client.Do(req) //nolint:gosec // URL is validated before request execution
+	if err != nil {
+		return nil, err
+	}
+	if f.redactor != nil {
+		f.redactor.LogResponse(resp, false)
+	}
+	return resp, nil
 }
 
 // Writer prepares WriteCloser for temporary file
@@ -70,7 +103,7 @@ func (f *FileDownloader) Download(ctx context.Context, url string) (string, erro
 	if err != nil {
 		return "", fmt.Errorf("cannot read URL: %s, %w", url, err)
 	}
-	resp, err := f.client.Do(req) //nolint:gosec // URL is validated before request execution
+	resp, err := f.do(req)
 	if err != nil {
 		return "", fmt.Errorf("cannot read URL: %s, %w", url, err)
 	}
@@ -103,7 +136,7 @@ func (f *FileDownloader) DownloadBytes(ctx context.Context, url string) ([]byte,
 	if err != nil {
 		return nil, fmt.Errorf("cannot read URL: %s, %w", url, err)
 	}
-	resp, err := f.client.Do(req) //nolint:gosec // URL is validated before request execution
+	resp, err := f.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("cannot read URL: %s, %w", url, err)
 	}
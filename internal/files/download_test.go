@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/trezorg/lingualeo/internal/httpclient"
 )
 
 func TestDownloadRejectsInvalidURL(t *testing.T) {
@@ -61,3 +62,17 @@ func TestDownloadBytesReadsResponseBody(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []byte("payload"), data)
 }
+
+func TestDownloadBytesWithDebugKeepsBody(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("payload"))
+	}))
+	defer server.Close()
+
+	d := New(server.Client(), WithDebug(httpclient.NewRedactor(nil, nil)))
+	data, err := d.DownloadBytes(t.Context(), server.URL)
+	require.NoError(t, err)
+	require.Equal(t, []byte("payload"), data)
+}
@@ -0,0 +1,243 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrCassetteMiss is returned in replay mode for requests missing from the cassette.
+var ErrCassetteMiss = errors.New("request is not recorded in the cassette")
+
+// CassetteMode selects whether a cassette records or replays HTTP traffic.
+type CassetteMode int
+
+const (
+	// CassetteRecord sends requests to the network and stores the exchanges.
+	CassetteRecord CassetteMode = iota
+	// CassetteReplay serves responses from the cassette without network.
+	CassetteReplay
+)
+
+// RecordedRequest is a stored HTTP request. Sensitive data is redacted.
+type RecordedRequest struct {
+	Headers http.Header `yaml:"headers,omitempty"`
+	Method  string      `yaml:"method"`
+	URL     string      `yaml:"url"`
+	Body    string      `yaml:"body,omitempty"`
+}
+
+// RecordedResponse is a stored HTTP response. Sensitive data is redacted.
+// Bodies that are not valid UTF-8, e.g. sounds and pictures, are stored in BodyBase64.
+type RecordedResponse struct {
+	Headers    http.Header `yaml:"headers,omitempty"`
+	Body       string      `yaml:"body,omitempty"`
+	BodyBase64 string      `yaml:"body_base64,omitempty"`
+	Status     int         `yaml:"status"`
+}
+
+// Interaction is a recorded request and response pair.
+type Interaction struct {
+	Request  RecordedRequest  `yaml:"request"`
+	Response RecordedResponse `yaml:"response"`
+}
+
+type cassetteFile struct {
+	Interactions []Interaction `yaml:"interactions"`
+}
+
+// Cassette stores HTTP interactions in a YAML file. JSON bodies are indented,
+// so recorded sessions can be reviewed and diffed.
+//
+// In replay mode requests are matched by method, URL and redacted body.
+// Repeated requests get the recorded responses in order; when they run out
+// the last matching response is served again.
+type Cassette struct {
+	redactor     *Redactor
+	path         string
+	interactions []Interaction
+	used         []bool
+	mode         CassetteMode
+	mu           sync.Mutex
+}
+
+// NewCassette creates a cassette for the file. In replay mode the file is loaded,
+// in record mode it is overwritten with every recorded interaction.
+// A nil redactor means NewRedactor(nil, nil).
+func NewCassette(path string, mode CassetteMode, redactor *Redactor) (*Cassette, error) {
+	if redactor == nil {
+		redactor = NewRedactor(nil, nil)
+	}
+	c := &Cassette{path: path, mode: mode, redactor: redactor}
+	if mode == CassetteRecord {
+		return c, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read cassette: %w", err)
+	}
+	file := cassetteFile{}
+	if err = yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse cassette %s: %w", path, err)
+	}
+	c.interactions = file.Interactions
+	c.used = make([]bool, len(c.interactions))
+	return c, nil
+}
+
+// Interactions returns a copy of the recorded or loaded interactions.
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// Transport wraps the next transport. In replay mode next is never called.
+func (c *Cassette) Transport(next http.RoundTripper) http.RoundTripper {
+	if c == nil {
+		return next
+	}
+	return &cassetteTransport{cassette: c, next: next}
+}
+
+type cassetteTransport struct {
+	cassette *Cassette
+	next     http.RoundTripper
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	recorded := t.cassette.request(req, body)
+	if t.cassette.mode == CassetteReplay {
+		return t.cassette.replay(req, recorded)
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if err = t.cassette.record(recorded, resp); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Cassette) request(req *http.Request, body []byte) RecordedRequest {
+	headers := c.redactor.Header(req.Header)
+	if len(headers) == 0 {
+		headers = nil
+	}
+	return RecordedRequest{
+		Method:  req.Method,
+		URL:     req.URL.String(),
+		Headers: headers,
+		Body:    string(prettyBody(c.redactor.Body(body))),
+	}
+}
+
+func (c *Cassette) matches(stored RecordedRequest, req RecordedRequest) bool {
+	return stored.Method == req.Method && stored.URL == req.URL &&
+		bytes.Equal(c.redactor.Body([]byte(stored.Body)), c.redactor.Body([]byte(req.Body)))
+}
+
+func (c *Cassette) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	found := -1
+	for i, interaction := range c.interactions {
+		if !c.matches(interaction.Request, recorded) {
+			continue
+		}
+		found = i
+		if !c.used[i] {
+			break
+		}
+	}
+	if found < 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrCassetteMiss, recorded.Method, recorded.URL)
+	}
+	c.used[found] = true
+	stored := c.interactions[found].Response
+	body := []byte(stored.Body)
+	if stored.BodyBase64 != "" {
+		decoded, err := base64.StdEncoding.DecodeString(stored.BodyBase64)
+		if err != nil {
+			return nil, fmt.Errorf("decode cassette body: %w", err)
+		}
+		body = decoded
+	}
+	header := stored.Headers.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", stored.Status, http.StatusText(stored.Status)),
+		StatusCode:    stored.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (c *Cassette) record(recorded RecordedRequest, resp *http.Response) error {
+	body, err := readBody(&resp.Body)
+	if err != nil {
+		return err
+	}
+	headers := c.redactor.Header(resp.Header)
+	headers.Del("Content-Length")
+	stored := RecordedResponse{Status: resp.StatusCode, Headers: headers}
+	if utf8.Valid(body) {
+		stored.Body = string(prettyBody(c.redactor.Body(body)))
+	} else {
+		stored.BodyBase64 = base64.StdEncoding.EncodeToString(body)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, Interaction{Request: recorded, Response: stored})
+	return c.save()
+}
+
+// save rewrites the whole file, so the recording survives an interrupted run.
+func (c *Cassette) save() error {
+	data, err := yaml.Marshal(cassetteFile{Interactions: c.interactions})
+	if err != nil {
+		return fmt.Errorf("encode cassette: %w", err)
+	}
+	if err = os.WriteFile(c.path, data, 0o600); err != nil {
+		return fmt.Errorf("write cassette: %w", err)
+	}
+	return nil
+}
+
+// prettyBody indents JSON bodies and keeps other ones as is.
+func prettyBody(body []byte) []byte {
+	var value any
+	if len(bytes.TrimSpace(body)) == 0 || json.Unmarshal(body, &value) != nil {
+		return body
+	}
+	pretty, err := json.Marshal(value, json.Deterministic(true), jsontext.WithIndent("  "))
+	if err != nil {
+		return body
+	}
+	return []byte(strings.TrimSpace(string(pretty)) + "\n")
+}
@@ -0,0 +1,102 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func cassetteGet(t *testing.T, client *http.Client, method string, url string, body string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(data)
+}
+
+func TestCassetteRecordsAndReplays(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	sound := string([]byte{0xff, 0xfb, 0x90, 0x00})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		switch r.URL.Path {
+		case "/auth":
+			http.SetCookie(w, &http.Cookie{Name: "remember", Value: "secret-cookie"})
+			_, _ = w.Write([]byte(`{"user":{"nickname":"user"}}`))
+		case "/translate":
+			_, _ = w.Write([]byte(`{"word":"hello","call":` + strconv.Itoa(int(n)) + `}`))
+		case "/sound.mp3":
+			_, _ = w.Write([]byte(sound))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.yaml")
+	recorder, err := NewCassette(path, CassetteRecord, nil)
+	require.NoError(t, err)
+	client, err := NewWithJar(Config{Cassette: recorder}, 0)
+	require.NoError(t, err)
+
+	_, authBody := cassetteGet(t, client, http.MethodPost, server.URL+"/auth", `{"email":"user@example.com","password":"secret"}`)
+	_, first := cassetteGet(t, client, http.MethodPost, server.URL+"/translate", `{"text":"hello","apiVersion":"1"}`)
+	_, second := cassetteGet(t, client, http.MethodPost, server.URL+"/translate", `{"apiVersion":"1","text":"hello"}`)
+	_, soundBody := cassetteGet(t, client, http.MethodGet, server.URL+"/sound.mp3", "")
+	require.Equal(t, sound, soundBody)
+	require.Len(t, recorder.Interactions(), 4)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "secret")
+	require.NotContains(t, string(data), "user@example.com")
+	require.Contains(t, string(data), "body_base64")
+
+	replayer, err := NewCassette(path, CassetteReplay, nil)
+	require.NoError(t, err)
+	replay, err := New(Config{Cassette: replayer})
+	require.NoError(t, err)
+	server.Close()
+
+	status, body := cassetteGet(t, replay, http.MethodPost, server.URL+"/auth", `{"email":"other@example.com","password":"other"}`)
+	require.Equal(t, http.StatusOK, status)
+	require.JSONEq(t, authBody, body)
+	_, body = cassetteGet(t, replay, http.MethodPost, server.URL+"/translate", `{"text":"hello","apiVersion":"1"}`)
+	require.JSONEq(t, first, body)
+	_, body = cassetteGet(t, replay, http.MethodPost, server.URL+"/translate", `{"text":"hello","apiVersion":"1"}`)
+	require.JSONEq(t, second, body)
+	_, body = cassetteGet(t, replay, http.MethodPost, server.URL+"/translate", `{"text":"hello","apiVersion":"1"}`)
+	require.JSONEq(t, second, body, "the last matching response is served again")
+	_, body = cassetteGet(t, replay, http.MethodGet, server.URL+"/sound.mp3", "")
+	require.Equal(t, sound, body)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, server.URL+"/translate", strings.NewReader(`{"text":"world"}`))
+	require.NoError(t, err)
+	resp, err := replay.Do(req)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	require.ErrorIs(t, err, ErrCassetteMiss)
+}
+
+func TestNewCassetteReplayRequiresFile(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewCassette(filepath.Join(t.TempDir(), "missing.yaml"), CassetteReplay, nil)
+	require.Error(t, err)
+}
@@ -4,10 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
-	"net/http/cookiejar"
 	"time"
-
-	"golang.org/x/net/publicsuffix"
 )
 
 var errRequestTimeout = errors.New("http request timeout")
@@ -20,25 +17,37 @@ const (
 )
 
 type Config struct {
+	// Limiter limits the request rate. Nil means no limit.
+	Limiter *Limiter
+	// MaxRetryAfter is the longest Retry-After delay that pauses the Limiter. Zero means no limit.
+	MaxRetryAfter time.Duration
+	// Cassette records or replays the traffic. Nil means plain network access.
+	Cassette *Cassette
+	// ProxyURL is an http, https or socks5 proxy. Empty means the HTTP_PROXY/HTTPS_PROXY env vars.
+	ProxyURL string
+	// NoProxy lists hosts, domains and CIDRs reached without the proxy. Empty means the NO_PROXY env var.
+	NoProxy []string
+	// CAFile is a PEM bundle trusted in addition to the system certificates.
+	CAFile string
+	// CertFile and KeyFile are the PEM client certificate and its key.
+	CertFile            string
+	KeyFile             string
+	DialTimeout         time.Duration
+	TLSHandshakeTimeout time.Duration
 	MaxIdleConns        int
 	MaxIdleConnsPerHost int
 }
 
 // New creates an HTTP client with connection pooling.
 // Timeouts should be handled via context at call sites, not at client level.
-func New(cfg Config) *http.Client {
-	if cfg.MaxIdleConns == 0 {
-		cfg.MaxIdleConns = DefaultMaxIdleConns
-	}
-	if cfg.MaxIdleConnsPerHost == 0 {
-		cfg.MaxIdleConnsPerHost = DefaultMaxIdleConnsHost
+func New(cfg Config) (*http.Client, error) {
+	transport, err := newTransport(cfg)
+	if err != nil {
+		return nil, err
 	}
 	return &http.Client{
-		Transport: &http.Transport{
-			MaxIdleConns:        cfg.MaxIdleConns,
-			MaxIdleConnsPerHost: cfg.MaxIdleConnsPerHost,
-		},
-	}
+		Transport: withLimiter(cfg.Cassette.Transport(transport), cfg.Limiter, cfg.MaxRetryAfter),
+	}, nil
 }
 
 // ErrRedirectLimit is returned when the redirect limit is exceeded.
@@ -47,45 +56,39 @@ var ErrRedirectLimit = errors.New("too many redirects")
 // NewWithJar creates an http.Client with cookie jar, connection pooling, and redirect policy.
 // This is the recommended client for API interactions that require cookie handling.
 func NewWithJar(cfg Config, maxRedirects int) (*http.Client, error) {
-	if cfg.MaxIdleConns == 0 {
-		cfg.MaxIdleConns = DefaultMaxIdleConns
-	}
-	if cfg.MaxIdleConnsPerHost == 0 {
-		cfg.MaxIdleConnsPerHost = DefaultMaxIdleConnsHost
-	}
-	if maxRedirects == 0 {
-		maxRedirects = DefaultMaxRedirects
+	jar, err := NewJar()
+	if err != nil {
+		return nil, err
 	}
+	return NewWithCookieJar(cfg, maxRedirects, jar)
+}
 
-	options := cookiejar.Options{
-		PublicSuffixList: publicsuffix.List,
-	}
-	jar, err := cookiejar.New(&options)
+// NewWithCookieJar is like NewWithJar but uses the provided cookie jar,
+// e.g. a Jar restored from a saved session.
+func NewWithCookieJar(cfg Config, maxRedirects int, jar http.CookieJar) (*http.Client, error) {
+	client, err := New(cfg)
 	if err != nil {
 		return nil, err
 	}
-
-	return &http.Client{
-		Jar: jar,
-		Transport: &http.Transport{
-			MaxIdleConns:        cfg.MaxIdleConns,
-			MaxIdleConnsPerHost: cfg.MaxIdleConnsPerHost,
-		},
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			if len(via) >= maxRedirects {
-				return ErrRedirectLimit
-			}
-			if len(via) == 0 {
-				return nil
-			}
-			for attr, val := range via[0].Header {
-				if _, ok := req.Header[attr]; !ok {
-					req.Header[attr] = val
-				}
-			}
+	if maxRedirects == 0 {
+		maxRedirects = DefaultMaxRedirects
+	}
+	client.Jar = jar
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return ErrRedirectLimit
+		}
+		if len(via) == 0 {
 			return nil
-		},
-	}, nil
+		}
+		for attr, val := range via[0].Header {
+			if _, ok := req.Header[attr]; !ok {
+				req.Header[attr] = val
+			}
+		}
+		return nil
+	}
+	return client, nil
 }
 
 // WithTimeout wraps a request with context timeout.
@@ -13,7 +13,8 @@ import (
 func TestNewUsesDefaults(t *testing.T) {
 	t.Parallel()
 
-	client := New(Config{})
+	client, err := New(Config{})
+	require.NoError(t, err)
 
 	transport, ok := client.Transport.(*http.Transport)
 	require.True(t, ok)
@@ -24,7 +25,8 @@ func TestNewUsesDefaults(t *testing.T) {
 func TestNewUsesProvidedValues(t *testing.T) {
 	t.Parallel()
 
-	client := New(Config{MaxIdleConns: 42, MaxIdleConnsPerHost: 7})
+	client, err := New(Config{MaxIdleConns: 42, MaxIdleConnsPerHost: 7})
+	require.NoError(t, err)
 
 	transport, ok := client.Transport.(*http.Transport)
 	require.True(t, ok)
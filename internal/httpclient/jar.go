@@ -0,0 +1,173 @@
+package httpclient
+
+import (
+	"encoding/json/v2"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+const (
+	jarDirMode  = 0o700
+	jarFileMode = 0o600
+)
+
+// storedCookie is the on-disk representation of a cookie received from a server.
+type storedCookie struct {
+	Expires  time.Time `json:"expires,omitzero"`
+	URL      string    `json:"url"`
+	Name     string    `json:"name"`
+	Value    string    `json:"value"`
+	Path     string    `json:"path,omitempty"`
+	Domain   string    `json:"domain,omitempty"`
+	Secure   bool      `json:"secure,omitempty"`
+	HTTPOnly bool      `json:"http_only,omitempty"`
+}
+
+func (c storedCookie) expired(now time.Time) bool {
+	return !c.Expires.IsZero() && !c.Expires.After(now)
+}
+
+func (c storedCookie) key() string {
+	return c.URL + "|" + c.Domain + "|" + c.Path + "|" + c.Name
+}
+
+func (c storedCookie) cookie() *http.Cookie {
+	return &http.Cookie{
+		Name:     c.Name,
+		Value:    c.Value,
+		Path:     c.Path,
+		Domain:   c.Domain,
+		Expires:  c.Expires,
+		Secure:   c.Secure,
+		HttpOnly: c.HTTPOnly,
+	}
+}
+
+// Jar is an http.CookieJar that remembers every cookie it receives,
+// so the session can be saved to a file and restored on the next run.
+type Jar struct {
+	jar     *cookiejar.Jar
+	cookies map[string]storedCookie
+	now     func() time.Time
+	mu      sync.Mutex
+}
+
+// NewJar creates an empty persistent cookie jar.
+func NewJar() (*Jar, error) {
+	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+	if err != nil {
+		return nil, err
+	}
+	return &Jar{
+		jar:     jar,
+		cookies: make(map[string]storedCookie),
+		now:     time.Now,
+	}, nil
+}
+
+// SetCookies implements http.CookieJar.
+func (j *Jar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.jar.SetCookies(u, cookies)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	origin := (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String()
+	now := j.now()
+	for _, c := range cookies {
+		stored := storedCookie{
+			URL:      origin,
+			Name:     c.Name,
+			Value:    c.Value,
+			Path:     c.Path,
+			Domain:   c.Domain,
+			Expires:  c.Expires,
+			Secure:   c.Secure,
+			HTTPOnly: c.HttpOnly,
+		}
+		switch {
+		case c.MaxAge < 0:
+			delete(j.cookies, stored.key())
+			continue
+		case c.MaxAge > 0:
+			stored.Expires = now.Add(time.Duration(c.MaxAge) * time.Second)
+		}
+		if stored.expired(now) {
+			delete(j.cookies, stored.key())
+			continue
+		}
+		j.cookies[stored.key()] = stored
+	}
+}
+
+// Cookies implements http.CookieJar.
+func (j *Jar) Cookies(u *url.URL) []*http.Cookie {
+	return j.jar.Cookies(u)
+}
+
+// Len returns the number of unexpired cookies held by the jar.
+func (j *Jar) Len() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	now := j.now()
+	count := 0
+	for _, c := range j.cookies {
+		if !c.expired(now) {
+			count++
+		}
+	}
+	return count
+}
+
+// Save writes unexpired cookies into the file, creating parent directories if needed.
+func (j *Jar) Save(filename string) error {
+	j.mu.Lock()
+	now := j.now()
+	cookies := make([]storedCookie, 0, len(j.cookies))
+	for _, c := range j.cookies {
+		if !c.expired(now) {
+			cookies = append(cookies, c)
+		}
+	}
+	j.mu.Unlock()
+
+	data, err := json.Marshal(cookies, json.Deterministic(true))
+	if err != nil {
+		return fmt.Errorf("encode cookies: %w", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(filename), jarDirMode); err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, jarFileMode)
+}
+
+// Load reads cookies saved by Save. Expired cookies are skipped.
+func (j *Jar) Load(filename string) error {
+	data, err := os.ReadFile(filename) //nolint:gosec // session file path is configured by the user
+	if err != nil {
+		return err
+	}
+	var cookies []storedCookie
+	if err = json.Unmarshal(data, &cookies); err != nil {
+		return fmt.Errorf("decode cookies: %w", err)
+	}
+	now := j.now()
+	for _, c := range cookies {
+		if c.expired(now) {
+			continue
+		}
+		u, pErr := url.Parse(c.URL)
+		if pErr != nil {
+			return fmt.Errorf("decode cookies: %w", pErr)
+		}
+		j.SetCookies(u, []*http.Cookie{c.cookie()})
+	}
+	return nil
+}
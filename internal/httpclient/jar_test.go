@@ -0,0 +1,68 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJarSaveAndLoadRestoresCookies(t *testing.T) {
+	t.Parallel()
+
+	u, err := url.Parse("https://lingualeo.com/api/auth")
+	require.NoError(t, err)
+	filename := filepath.Join(t.TempDir(), "state", "session.json")
+
+	jar, err := NewJar()
+	require.NoError(t, err)
+	jar.SetCookies(u, []*http.Cookie{
+		{Name: "remember", Value: "token", Path: "/", Expires: time.Now().Add(time.Hour)},
+		{Name: "session", Value: "abc", Path: "/"},
+		{Name: "stale", Value: "old", Path: "/", MaxAge: -1},
+	})
+	require.Equal(t, 2, jar.Len())
+	require.NoError(t, jar.Save(filename))
+
+	restored, err := NewJar()
+	require.NoError(t, err)
+	require.NoError(t, restored.Load(filename))
+	require.Equal(t, 2, restored.Len())
+
+	cookies := restored.Cookies(&url.URL{Scheme: "https", Host: "lingualeo.com", Path: "/"})
+	values := make(map[string]string, len(cookies))
+	for _, c := range cookies {
+		values[c.Name] = c.Value
+	}
+	require.Equal(t, map[string]string{"remember": "token", "session": "abc"}, values)
+}
+
+func TestJarLoadSkipsExpiredCookies(t *testing.T) {
+	t.Parallel()
+
+	u, err := url.Parse("https://lingualeo.com/")
+	require.NoError(t, err)
+	filename := filepath.Join(t.TempDir(), "session.json")
+
+	jar, err := NewJar()
+	require.NoError(t, err)
+	jar.SetCookies(u, []*http.Cookie{{Name: "remember", Value: "token", MaxAge: 60}})
+	require.NoError(t, jar.Save(filename))
+
+	restored, err := NewJar()
+	require.NoError(t, err)
+	restored.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	require.NoError(t, restored.Load(filename))
+	require.Zero(t, restored.Len())
+}
+
+func TestJarLoadMissingFile(t *testing.T) {
+	t.Parallel()
+
+	jar, err := NewJar()
+	require.NoError(t, err)
+	require.Error(t, jar.Load(filepath.Join(t.TempDir(), "missing.json")))
+}
@@ -0,0 +1,146 @@
+package httpclient
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket limiting the rate of HTTP requests.
+// A single Limiter can be shared by several clients, so that API calls
+// and file downloads draw from the same budget.
+// A nil *Limiter does not limit anything.
+type Limiter struct {
+	last   time.Time
+	paused time.Time
+	now    func() time.Time
+	rate   float64
+	burst  float64
+	tokens float64
+	mu     sync.Mutex
+}
+
+// NewLimiter creates a limiter allowing rate requests per second with bursts of up to burst requests.
+// It returns nil, i.e. no limit, when rate is not positive.
+func NewLimiter(rate float64, burst int) *Limiter {
+	if rate <= 0 {
+		return nil
+	}
+	b := math.Max(float64(burst), 1)
+	return &Limiter{
+		rate:   rate,
+		burst:  b,
+		tokens: b,
+		now:    time.Now,
+	}
+}
+
+// reserve takes a token and returns how long the caller has to wait before using it.
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	l.tokens--
+
+	wait := time.Duration(0)
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	return max(wait, l.paused.Sub(now))
+}
+
+// cancel returns a token taken by reserve that was not used.
+func (l *Limiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = math.Min(l.burst, l.tokens+1)
+}
+
+// Wait blocks until a request is allowed or ctx is done.
+func (l *Limiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return ctx.Err()
+	}
+	wait := l.reserve()
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Pause holds back every request for d, e.g. after the server asked to retry later.
+func (l *Limiter) Pause(d time.Duration) {
+	if l == nil || d <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := l.now().Add(d); until.After(l.paused) {
+		l.paused = until
+	}
+}
+
+// RetryAfter returns the delay requested by the Retry-After header of a 429 or 503 response.
+// The header holds either a number of seconds or an HTTP date.
+func RetryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil || (resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable) {
+		return 0, false
+	}
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// limitedTransport waits for the limiter before every request
+// and pauses the limiter when the server asks to retry later.
+// Delays longer than maxPause, if set, are not waited for: such requests are not retried.
+type limitedTransport struct {
+	next     http.RoundTripper
+	limiter  *Limiter
+	maxPause time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	resp, err := t.next.RoundTrip(req)
+	if delay, ok := RetryAfter(resp, time.Now()); ok && (t.maxPause <= 0 || delay <= t.maxPause) {
+		t.limiter.Pause(delay)
+	}
+	return resp, err
+}
+
+// withLimiter wraps transport with the limiter, if any.
+func withLimiter(transport http.RoundTripper, limiter *Limiter, maxPause time.Duration) http.RoundTripper {
+	if limiter == nil {
+		return transport
+	}
+	return limitedTransport{next: transport, limiter: limiter, maxPause: maxPause}
+}
@@ -0,0 +1,145 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(rate float64, burst int, now *time.Time) *Limiter {
+	l := NewLimiter(rate, burst)
+	l.now = func() time.Time { return *now }
+	return l
+}
+
+func TestNewLimiterWithoutRateIsUnlimited(t *testing.T) {
+	t.Parallel()
+
+	l := NewLimiter(0, 10)
+	require.Nil(t, l)
+	require.NoError(t, l.Wait(t.Context()))
+	l.Pause(time.Hour)
+}
+
+func TestLimiterAllowsBurstThenSpacesRequests(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	l := newTestLimiter(2, 2, &now)
+
+	require.Zero(t, l.reserve())
+	require.Zero(t, l.reserve())
+	require.Equal(t, 500*time.Millisecond, l.reserve())
+	require.Equal(t, time.Second, l.reserve())
+
+	now = now.Add(2 * time.Second)
+	require.Zero(t, l.reserve())
+}
+
+func TestLimiterPauseHoldsBackRequests(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	l := newTestLimiter(100, 10, &now)
+
+	l.Pause(3 * time.Second)
+	l.Pause(time.Second)
+	require.Equal(t, 3*time.Second, l.reserve())
+
+	now = now.Add(3 * time.Second)
+	require.Zero(t, l.reserve())
+}
+
+func TestLimiterWaitReturnsOnCancel(t *testing.T) {
+	t.Parallel()
+
+	l := NewLimiter(1, 1)
+	l.Pause(time.Hour)
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	require.ErrorIs(t, l.Wait(ctx), context.Canceled)
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	response := func(status int, value string) *http.Response {
+		resp := &http.Response{StatusCode: status, Header: http.Header{}}
+		if value != "" {
+			resp.Header.Set("Retry-After", value)
+		}
+		return resp
+	}
+
+	tests := []struct {
+		name  string
+		resp  *http.Response
+		delay time.Duration
+		ok    bool
+	}{
+		{name: "seconds", resp: response(http.StatusTooManyRequests, "7"), delay: 7 * time.Second, ok: true},
+		{name: "http date", resp: response(http.StatusServiceUnavailable, now.Add(time.Minute).Format(http.TimeFormat)), delay: time.Minute, ok: true},
+		{name: "date in the past", resp: response(http.StatusTooManyRequests, now.Add(-time.Minute).Format(http.TimeFormat)), ok: true},
+		{name: "missing header", resp: response(http.StatusTooManyRequests, "")},
+		{name: "invalid header", resp: response(http.StatusTooManyRequests, "soon")},
+		{name: "ok status", resp: response(http.StatusOK, "7")},
+		{name: "no response"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, ok := RetryAfter(tt.resp, now)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.delay, delay)
+		})
+	}
+}
+
+func TestClientWithLimiterPausesOnRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	limiter := NewLimiter(100, 10)
+	client, err := New(Config{Limiter: limiter})
+	require.NoError(t, err)
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	require.Greater(t, limiter.reserve(), 59*time.Second)
+}
+
+func TestClientWithLimiterIgnoresRetryAfterAboveMax(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	limiter := NewLimiter(100, 10)
+	client, err := New(Config{Limiter: limiter, MaxRetryAfter: time.Minute})
+	require.NoError(t, err)
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+	require.NoError(t, limiter.Wait(ctx))
+}
@@ -0,0 +1,174 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/json/v2"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"slices"
+	"strings"
+)
+
+// Redacted replaces sensitive values in dumps.
+const Redacted = "[REDACTED]"
+
+var (
+	// DefaultSensitiveHeaders are headers always redacted from dumps.
+	DefaultSensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+	// DefaultSensitiveFields are JSON fields and query parameters always redacted from dumps.
+	DefaultSensitiveFields = []string{"email", "password", "token", "access_token", "refresh_token"}
+)
+
+// Redactor dumps HTTP requests and responses for debugging with credentials
+// and cookies replaced by Redacted.
+type Redactor struct {
+	headers []string
+	fields  []string
+}
+
+// NewRedactor creates a redactor for the default sensitive headers and fields plus the given ones.
+// Headers and fields are matched case-insensitively.
+func NewRedactor(headers []string, fields []string) *Redactor {
+	r := &Redactor{}
+	for _, h := range slices.Concat(DefaultSensitiveHeaders, headers) {
+		r.headers = append(r.headers, http.CanonicalHeaderKey(strings.TrimSpace(h)))
+	}
+	for _, f := range slices.Concat(DefaultSensitiveFields, fields) {
+		r.fields = append(r.fields, strings.ToLower(strings.TrimSpace(f)))
+	}
+	slices.Sort(r.headers)
+	slices.Sort(r.fields)
+	r.headers = slices.Compact(r.headers)
+	r.fields = slices.Compact(r.fields)
+	return r
+}
+
+func (r *Redactor) sensitiveField(name string) bool {
+	_, found := slices.BinarySearch(r.fields, strings.ToLower(name))
+	return found
+}
+
+// Header returns a copy of the header with sensitive values redacted.
+func (r *Redactor) Header(header http.Header) http.Header {
+	res := header.Clone()
+	for _, name := range r.headers {
+		if values, ok := res[name]; ok {
+			for i := range values {
+				values[i] = Redacted
+			}
+		}
+	}
+	return res
+}
+
+// Body returns the body with sensitive JSON fields redacted at any depth.
+// Bodies that are not JSON are returned as is.
+func (r *Redactor) Body(body []byte) []byte {
+	var value any
+	if len(bytes.TrimSpace(body)) == 0 || json.Unmarshal(body, &value) != nil {
+		return body
+	}
+	redacted, err := json.Marshal(r.value(value), json.Deterministic(true))
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+func (r *Redactor) value(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if r.sensitiveField(key) {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = r.value(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = r.value(item)
+		}
+	}
+	return value
+}
+
+// readBody reads the body and puts an unread copy back, so the caller can still send or read it.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, err
+}
+
+// DumpRequest dumps an outgoing request with sensitive data redacted.
+// The request body stays readable.
+func (r *Redactor) DumpRequest(req *http.Request, body bool) ([]byte, error) {
+	clone := req.Clone(req.Context())
+	clone.Header = r.Header(req.Header)
+	if req.URL != nil {
+		query := clone.URL.Query()
+		for name := range query {
+			if r.sensitiveField(name) {
+				query[name] = []string{Redacted}
+			}
+		}
+		clone.URL.RawQuery = query.Encode()
+	}
+	clone.Body = nil
+	if body {
+		data, err := readBody(&req.Body)
+		if err != nil {
+			return nil, err
+		}
+		if data != nil {
+			redacted := r.Body(data)
+			clone.Body = io.NopCloser(bytes.NewReader(redacted))
+			clone.ContentLength = int64(len(redacted))
+		}
+	}
+	return httputil.DumpRequestOut(clone, body)
+}
+
+// DumpResponse dumps a response with sensitive data redacted.
+// The response body stays readable.
+func (r *Redactor) DumpResponse(resp *http.Response, body bool) ([]byte, error) {
+	clone := *resp
+	clone.Header = r.Header(resp.Header)
+	clone.Body = nil
+	if body {
+		data, err := readBody(&resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		redacted := r.Body(data)
+		clone.Body = io.NopCloser(bytes.NewReader(redacted))
+		clone.ContentLength = int64(len(redacted))
+	}
+	return httputil.DumpResponse(&clone, body)
+}
+
+// LogRequest logs the redacted request dump at debug level.
+func (r *Redactor) LogRequest(req *http.Request, body bool) {
+	dump, err := r.DumpRequest(req, body)
+	if err != nil {
+		slog.Error("cannot dump http request", "error", err)
+		return
+	}
+	slog.Debug(string(dump))
+}
+
+// LogResponse logs the redacted response dump at debug level.
+func (r *Redactor) LogResponse(resp *http.Response, body bool) {
+	dump, err := r.DumpResponse(resp, body)
+	if err != nil {
+		slog.Error("cannot dump http response", "error", err)
+		return
+	}
+	slog.Debug(string(dump))
+}
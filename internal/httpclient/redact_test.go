@@ -0,0 +1,87 @@
+package httpclient
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactorDumpRequest(t *testing.T) {
+	t.Parallel()
+
+	body := `{"email":"user@example.com","password":"secret","data":[{"Token":"abc","word":"hello"}]}`
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost,
+		"https://api.example.com/auth?password=secret&page=1", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Cookie", "remember=secret-cookie")
+	req.Header.Set("Authorization", "Bearer secret-token")
+	req.Header.Set("X-Api-Key", "secret-key")
+	req.Header.Set("Accept", "application/json")
+
+	dump, err := NewRedactor([]string{"x-api-key"}, nil).DumpRequest(req, true)
+	require.NoError(t, err)
+	require.NotContains(t, string(dump), "secret")
+	require.NotContains(t, string(dump), "user@example.com")
+	require.NotContains(t, string(dump), "abc")
+	require.Contains(t, string(dump), "Accept: application/json")
+	require.Contains(t, string(dump), `"word":"hello"`)
+	require.Contains(t, string(dump), "page=1")
+	require.Contains(t, string(dump), Redacted)
+
+	sent, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.JSONEq(t, body, string(sent), "request body must stay intact")
+	require.Equal(t, "remember=secret-cookie", req.Header.Get("Cookie"))
+	require.Contains(t, req.URL.RawQuery, "password=secret")
+}
+
+func TestRedactorDumpResponse(t *testing.T) {
+	t.Parallel()
+
+	body := `{"user":{"email":"user@example.com","nickname":"user"}}`
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header: http.Header{
+			"Set-Cookie":   {"remember=secret-cookie; Path=/"},
+			"Content-Type": {"application/json"},
+		},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+
+	dump, err := NewRedactor(nil, nil).DumpResponse(resp, true)
+	require.NoError(t, err)
+	require.NotContains(t, string(dump), "secret-cookie")
+	require.NotContains(t, string(dump), "user@example.com")
+	require.Contains(t, string(dump), `"nickname":"user"`)
+
+	read, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, body, string(read), "response body must stay intact")
+}
+
+func TestRedactorBodyKeepsNonJSON(t *testing.T) {
+	t.Parallel()
+
+	body := []byte("email=user@example.com")
+	require.True(t, bytes.Equal(body, NewRedactor(nil, []string{"email"}).Body(body)))
+}
+
+func TestRedactorDumpWithoutBody(t *testing.T) {
+	t.Parallel()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "https://cdn.example.com/sound.mp3", nil)
+	require.NoError(t, err)
+	req.Header.Set("Cookie", "remember=secret-cookie")
+
+	dump, err := NewRedactor(nil, nil).DumpRequest(req, false)
+	require.NoError(t, err)
+	require.NotContains(t, string(dump), "secret-cookie")
+	require.Contains(t, string(dump), "GET /sound.mp3")
+}
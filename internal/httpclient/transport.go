@@ -0,0 +1,123 @@
+package httpclient
+
+import (
+	"cmp"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+const (
+	DefaultDialTimeout         = 30 * time.Second
+	DefaultTLSHandshakeTimeout = 10 * time.Second
+	defaultKeepAlive           = 30 * time.Second
+)
+
+var (
+	errProxyURL          = errors.New("invalid proxy URL")
+	errCAFile            = errors.New("invalid CA file")
+	errClientCertificate = errors.New("invalid client certificate")
+)
+
+// proxySchemes are the proxy URL schemes supported by http.Transport.
+var proxySchemes = []string{"http", "https", "socks5", "socks5h"}
+
+// newTransport builds the transport with the proxy, TLS and timeout settings of the config.
+func newTransport(cfg Config) (*http.Transport, error) {
+	proxy, err := cfg.proxy()
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{
+		Timeout:   cmp.Or(cfg.DialTimeout, DefaultDialTimeout),
+		KeepAlive: defaultKeepAlive,
+	}
+	return &http.Transport{
+		Proxy:               proxy,
+		DialContext:         dialer.DialContext,
+		TLSClientConfig:     tlsConfig,
+		TLSHandshakeTimeout: cmp.Or(cfg.TLSHandshakeTimeout, DefaultTLSHandshakeTimeout),
+		ForceAttemptHTTP2:   true,
+		MaxIdleConns:        cmp.Or(cfg.MaxIdleConns, DefaultMaxIdleConns),
+		MaxIdleConnsPerHost: cmp.Or(cfg.MaxIdleConnsPerHost, DefaultMaxIdleConnsHost),
+	}, nil
+}
+
+// proxy returns the proxy selector. Settings missing from the config
+// are taken from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY env vars.
+func (c Config) proxy() (func(*http.Request) (*url.URL, error), error) {
+	proxyConfig := httpproxy.FromEnvironment()
+	if c.ProxyURL != "" {
+		u, err := url.Parse(c.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errProxyURL, err)
+		}
+		if !isProxyScheme(u.Scheme) || u.Host == "" {
+			return nil, fmt.Errorf("%w: %s, allowed schemes: %v", errProxyURL, c.ProxyURL, proxySchemes)
+		}
+		proxyConfig.HTTPProxy = c.ProxyURL
+		proxyConfig.HTTPSProxy = c.ProxyURL
+	}
+	if len(c.NoProxy) > 0 {
+		proxyConfig.NoProxy = strings.Join(c.NoProxy, ",")
+	}
+	proxyFunc := proxyConfig.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
+
+func isProxyScheme(scheme string) bool {
+	for _, s := range proxySchemes {
+		if strings.EqualFold(s, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// tlsConfig returns nil when the config has no TLS settings, so Go defaults are used.
+func (c Config) tlsConfig() (*tls.Config, error) {
+	if c.CAFile == "" && c.CertFile == "" && c.KeyFile == "" {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.CAFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errCAFile, err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: no PEM certificates in %s", errCAFile, c.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		if c.CertFile == "" || c.KeyFile == "" {
+			return nil, fmt.Errorf("%w: both certificate and key files are required", errClientCertificate)
+		}
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errClientCertificate, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,106 @@
+package httpclient
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSendsRequestsThroughProxy(t *testing.T) {
+	t.Parallel()
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("proxied " + r.URL.String()))
+	}))
+	defer proxy.Close()
+
+	client, err := New(Config{ProxyURL: proxy.URL, NoProxy: []string{"direct.invalid"}})
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://api.lingualeo.invalid/translate", nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "proxied http://api.lingualeo.invalid/translate", string(body))
+
+	transport, ok := client.Transport.(*http.Transport)
+	require.True(t, ok)
+	req, err = http.NewRequestWithContext(t.Context(), http.MethodGet, "http://direct.invalid/", nil)
+	require.NoError(t, err)
+	proxyURL, err := transport.Proxy(req)
+	require.NoError(t, err)
+	require.Nil(t, proxyURL)
+}
+
+func TestNewRejectsInvalidProxyURL(t *testing.T) {
+	t.Parallel()
+
+	for _, proxy := range []string{"ftp://proxy:21", "localhost:3128", "://"} {
+		_, err := New(Config{ProxyURL: proxy})
+		require.ErrorIs(t, err, errProxyURL, proxy)
+	}
+	_, err := New(Config{ProxyURL: "socks5://localhost:1080"})
+	require.NoError(t, err)
+}
+
+func TestNewTrustsCAFile(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, certPEM, 0o600))
+
+	get := func(client *http.Client) error {
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	plain, err := New(Config{})
+	require.NoError(t, err)
+	require.Error(t, get(plain))
+
+	trusting, err := New(Config{CAFile: caFile, DialTimeout: time.Second, TLSHandshakeTimeout: 2 * time.Second})
+	require.NoError(t, err)
+	require.NoError(t, get(trusting))
+
+	transport, ok := trusting.Transport.(*http.Transport)
+	require.True(t, ok)
+	require.Equal(t, 2*time.Second, transport.TLSHandshakeTimeout)
+}
+
+func TestNewRejectsInvalidTLSFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.pem")
+	require.NoError(t, os.WriteFile(invalid, []byte("not a certificate"), 0o600))
+
+	_, err := New(Config{CAFile: invalid})
+	require.ErrorIs(t, err, errCAFile)
+	_, err = New(Config{CAFile: filepath.Join(dir, "missing.pem")})
+	require.ErrorIs(t, err, errCAFile)
+	_, err = New(Config{CertFile: invalid})
+	require.ErrorIs(t, err, errClientCertificate)
+	_, err = New(Config{CertFile: invalid, KeyFile: invalid})
+	require.ErrorIs(t, err, errClientCertificate)
+}
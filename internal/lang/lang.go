@@ -0,0 +1,114 @@
+// Package lang describes the languages of translated words.
+package lang
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+var (
+	errLanguage     = errors.New("unknown language")
+	errLanguagePair = errors.New("source and target languages must differ")
+)
+
+// Language is a language with the script its words are written in.
+type Language struct {
+	Script *unicode.RangeTable
+	Code   string
+	Name   string
+}
+
+var (
+	English    = Language{Code: "en", Name: "English", Script: unicode.Latin}
+	Russian    = Language{Code: "ru", Name: "Russian", Script: unicode.Cyrillic}
+	Ukrainian  = Language{Code: "uk", Name: "Ukrainian", Script: unicode.Cyrillic}
+	Belarusian = Language{Code: "be", Name: "Belarusian", Script: unicode.Cyrillic}
+	Kazakh     = Language{Code: "kk", Name: "Kazakh", Script: unicode.Cyrillic}
+	Portuguese = Language{Code: "pt", Name: "Portuguese", Script: unicode.Latin}
+	Spanish    = Language{Code: "es", Name: "Spanish", Script: unicode.Latin}
+	French     = Language{Code: "fr", Name: "French", Script: unicode.Latin}
+	German     = Language{Code: "de", Name: "German", Script: unicode.Latin}
+	Italian    = Language{Code: "it", Name: "Italian", Script: unicode.Latin}
+	Turkish    = Language{Code: "tr", Name: "Turkish", Script: unicode.Latin}
+)
+
+// Languages lists the languages accepted by Parse.
+var Languages = []Language{
+	English, Russian, Ukrainian, Belarusian, Kazakh, Portuguese, Spanish, French, German, Italian, Turkish,
+}
+
+// Codes returns the codes of the known languages.
+func Codes() []string {
+	codes := make([]string, 0, len(Languages))
+	for _, l := range Languages {
+		codes = append(codes, l.Code)
+	}
+	return codes
+}
+
+// Parse returns the language by its ISO 639-1 code or English name.
+func Parse(s string) (Language, error) {
+	s = strings.TrimSpace(s)
+	idx := slices.IndexFunc(Languages, func(l Language) bool {
+		return strings.EqualFold(l.Code, s) || strings.EqualFold(l.Name, s)
+	})
+	if idx < 0 {
+		return Language{}, fmt.Errorf("%w: %s, allowed: %v", errLanguage, s, Codes())
+	}
+	return Languages[idx], nil
+}
+
+func (l Language) String() string {
+	return l.Code
+}
+
+// IsWord reports whether the word consists of letters of the language script and numbers only.
+func (l Language) IsWord(s string) bool {
+	for _, symbol := range s {
+		if !unicode.Is(l.Script, symbol) && !unicode.Is(unicode.Number, symbol) {
+			return false
+		}
+	}
+	return true
+}
+
+// Pair is the language being learned and the native language translations are given in.
+type Pair struct {
+	Source Language
+	Target Language
+}
+
+// DefaultPair is English learned by a Russian speaker.
+var DefaultPair = Pair{Source: English, Target: Russian}
+
+// ParsePair parses the source and target language codes. Empty codes mean DefaultPair languages.
+func ParsePair(source string, target string) (Pair, error) {
+	pair := DefaultPair
+	var err error
+	if source != "" {
+		if pair.Source, err = Parse(source); err != nil {
+			return Pair{}, err
+		}
+	}
+	if target != "" {
+		if pair.Target, err = Parse(target); err != nil {
+			return Pair{}, err
+		}
+	}
+	if pair.Source.Code == pair.Target.Code {
+		return Pair{}, fmt.Errorf("%w: %s", errLanguagePair, pair.Source.Code)
+	}
+	return pair, nil
+}
+
+func (p Pair) String() string {
+	return p.Source.Code + "-" + p.Target.Code
+}
+
+// DistinctScripts reports whether words of the languages can be told apart by their script.
+func (p Pair) DistinctScripts() bool {
+	return p.Source.Script != p.Target.Script
+}
@@ -0,0 +1,158 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	l, err := Parse("UK")
+	require.NoError(t, err)
+	assert.Equal(t, Ukrainian, l)
+
+	l, err = Parse("portuguese")
+	require.NoError(t, err)
+	assert.Equal(t, Portuguese, l)
+
+	_, err = Parse("klingon")
+	require.ErrorIs(t, err, errLanguage)
+}
+
+func TestParsePair(t *testing.T) {
+	t.Parallel()
+
+	pair, err := ParsePair("", "")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPair, pair)
+	assert.Equal(t, "en-ru", pair.String())
+	assert.True(t, pair.DistinctScripts())
+
+	pair, err = ParsePair("en", "pt")
+	require.NoError(t, err)
+	assert.Equal(t, Pair{Source: English, Target: Portuguese}, pair)
+	assert.False(t, pair.DistinctScripts())
+
+	_, err = ParsePair("ru", "")
+	require.ErrorIs(t, err, errLanguagePair)
+	_, err = ParsePair("", "xx")
+	require.ErrorIs(t, err, errLanguage)
+}
+
+func TestIsWord(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		language Language
+		word     string
+		expected bool
+	}{
+		{language: Ukrainian, word: "їжак", expected: true},
+		{language: Ukrainian, word: "hedgehog", expected: false},
+		{language: Portuguese, word: "coração", expected: true},
+		{language: Portuguese, word: "сердце", expected: false},
+		{language: English, word: "test1", expected: true},
+		{language: English, word: "two words", expected: false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, tt.language.IsWord(tt.word), "%s %s", tt.language, tt.word)
+	}
+}
+
+func TestRussianWord(t *testing.T) {
+	tests := []struct {
+		name     string
+		word     string
+		expected bool
+	}{
+		// Basic Russian words
+		{name: "simple russian word", word: "гном", expected: true},
+		{name: "russian word with number", word: "гном1", expected: true},
+		{name: "russian word with multiple numbers", word: "слово123", expected: true},
+		{name: "only numbers", word: "12345", expected: true},
+		{name: "russian uppercase", word: "МОСКВА", expected: true},
+		{name: "russian mixed case", word: "МосквА", expected: true},
+
+		// English words
+		{name: "simple english word", word: "test", expected: false},
+		{name: "english word with number", word: "test1", expected: false},
+		{name: "english uppercase", word: "HELLO", expected: false},
+
+		// Mixed Cyrillic/Latin
+		{name: "mixed cyrillic latin", word: "testгном", expected: false},
+		{name: "mixed latin cyrillic", word: "гномtest", expected: false},
+
+		// Special characters
+		{name: "with hyphen", word: "как-то", expected: false},
+		{name: "with space", word: "привет мир", expected: false},
+		{name: "with punctuation", word: "привет!", expected: false},
+		{name: "with underscore", word: "при_вет", expected: false},
+
+		// Empty and edge cases
+		{name: "empty string", word: "", expected: true}, // Empty passes because loop doesn't run
+		{name: "single cyrillic char", word: "а", expected: true},
+		{name: "single number", word: "1", expected: true},
+
+		// Unicode edge cases
+		{name: "russian yo", word: "ёлка", expected: true},
+		{name: "russian hard sign", word: "съезд", expected: true},
+		{name: "russian soft sign", word: "день", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Russian.IsWord(tt.word))
+		})
+	}
+}
+
+func TestEnglishWord(t *testing.T) {
+	tests := []struct {
+		name     string
+		word     string
+		expected bool
+	}{
+		// Basic English words
+		{name: "simple english word", word: "hello", expected: true},
+		{name: "english word with number", word: "test1", expected: true},
+		{name: "english word with multiple numbers", word: "word123", expected: true},
+		{name: "only numbers", word: "12345", expected: true},
+		{name: "english uppercase", word: "HELLO", expected: true},
+		{name: "english mixed case", word: "HeLLo", expected: true},
+
+		// Russian words
+		{name: "simple russian word", word: "гном", expected: false},
+		{name: "russian word with number", word: "гном1", expected: false},
+		{name: "russian uppercase", word: "МОСКВА", expected: false},
+
+		// Mixed Cyrillic/Latin
+		{name: "mixed cyrillic latin", word: "testгном", expected: false},
+		{name: "mixed latin cyrillic", word: "гномtest", expected: false},
+
+		// Special characters
+		{name: "with hyphen", word: "some-thing", expected: false},
+		{name: "with space", word: "hello world", expected: false},
+		{name: "with punctuation", word: "hello!", expected: false},
+		{name: "with underscore", word: "hello_world", expected: false},
+
+		// Empty and edge cases
+		{name: "empty string", word: "", expected: true}, // Empty passes because loop doesn't run
+		{name: "single latin char", word: "a", expected: true},
+		{name: "single number", word: "1", expected: true},
+
+		// Unicode edge cases - these are Latin script variants
+		{name: "german umlaut", word: "größe", expected: true},
+		{name: "french accent", word: "café", expected: true},
+		{name: "spanish tilde", word: "año", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, English.IsWord(tt.word))
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package messages
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
 	"github.com/fatih/color"
 )
 
@@ -26,12 +31,58 @@ var colors = map[Color]*color.Color{
 	WHITE:  color.New(color.FgWhite),
 }
 
-// Message shows a message with color package
-func Message(c Color, message string, params ...any) error {
-	col, ok := colors[c]
+// colorNames are the names of colors, e.g. for templates
+var colorNames = map[string]Color{
+	"red":    RED,
+	"green":  GREEN,
+	"yellow": YELLOW,
+	"white":  WHITE,
+}
+
+var errUnknownColor = errors.New("unknown color")
+
+// ParseColor returns the color by its name: red, green, yellow or white
+func ParseColor(name string) (Color, error) {
+	c, ok := colorNames[strings.ToLower(name)]
 	if !ok {
-		col = color.New(color.Reset)
+		return 0, fmt.Errorf("%w: %s", errUnknownColor, name)
 	}
-	_, err := col.Printf(message, params...)
+	return c, nil
+}
+
+// Colorize returns the text in color. Colors are omitted when they are disabled
+func Colorize(c Color, text string) string {
+	return colorOf(c).Sprint(text)
+}
+
+// output receives messages, stdout by default
+var output = color.Output
+
+// SetOutput redirects messages, e.g. to stderr when stdout carries machine-readable output
+func SetOutput(w io.Writer) {
+	output = w
+}
+
+// Output returns the writer receiving messages
+func Output() io.Writer {
+	return output
+}
+
+func colorOf(c Color) *color.Color {
+	if col, ok := colors[c]; ok {
+		return col
+	}
+	return color.New(color.Reset)
+}
+
+// Message shows a message with color package
+func Message(c Color, message string, params ...any) error {
+	_, err := colorOf(c).Fprintf(output, message, params...)
+	return err
+}
+
+// MessageTo is like Message but writes to w
+func MessageTo(w io.Writer, c Color, message string, params ...any) error {
+	_, err := colorOf(c).Fprintf(w, message, params...)
 	return err
 }
@@ -1,6 +1,7 @@
 package messages
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -15,3 +16,22 @@ func TestMessageSupportsKnownAndFallbackColors(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func TestMessageToWritesIntoWriter(t *testing.T) {
+	t.Parallel()
+
+	out := &strings.Builder{}
+	require.NoError(t, MessageTo(out, GREEN, "%s", "ok"))
+	require.Contains(t, out.String(), "ok")
+}
+
+func TestParseColor(t *testing.T) {
+	t.Parallel()
+
+	c, err := ParseColor("Yellow")
+	require.NoError(t, err)
+	require.Equal(t, YELLOW, c)
+	_, err = ParseColor("purple")
+	require.ErrorIs(t, err, errUnknownColor)
+	require.Contains(t, Colorize(c, "ok"), "ok")
+}
@@ -6,7 +6,7 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/google/shlex"
+	"github.com/trezorg/lingualeo/internal/command"
 )
 
 const (
@@ -29,17 +29,8 @@ func WithShutdownTimeout(d time.Duration) Option {
 	}
 }
 
-func ParseCommand(command string) (string, []string) {
-	parts, err := shlex.Split(command)
-	if err != nil || len(parts) == 0 {
-		return "", []string{}
-	}
-
-	return parts[0], parts[1:]
-}
-
 func New(player string, opts ...Option) Player {
-	execName, params := ParseCommand(player)
+	execName, params := command.Parse(player)
 	p := Player{
 		player:          execName,
 		params:          params,
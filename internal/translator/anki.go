@@ -0,0 +1,208 @@
+package translator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/messages"
+)
+
+const (
+	// AnkiFormat exports cards as an Anki text file with a media folder.
+	AnkiFormat = "anki"
+
+	ankiCardsFile      = "cards.txt"
+	ankiMediaDir       = "media"
+	ankiMediaPrefix    = "lingualeo-"
+	ankiMediaHashSize  = 12
+	defaultSoundExt    = ".mp3"
+	defaultPictureExt  = ".jpg"
+	defaultExportDir   = "lingualeo-anki"
+	ankiCardsSeparator = '\t'
+)
+
+var (
+	errExportFormat  = errors.New("unknown export format, allowed formats: " + AnkiFormat)
+	errMediaDownload = errors.New("cannot download media")
+)
+
+// ankiHeader lets Anki import the file without questions: Basic notes,
+// tab separated HTML fields.
+var ankiHeader = []string{"#separator:tab", "#html:true", "#notetype:Basic"}
+
+// AnkiExport is an outputer writing a card per word into the cards.txt file of dir.
+// The front of a card holds the word and its pronunciation, the back holds the
+// transcription, translations with context and the first picture.
+// Media are downloaded into the media folder to be copied into collection.media.
+type AnkiExport struct {
+	downloader Downloader
+	file       *os.File
+	cards      *csv.Writer
+	dir        string
+	mu         sync.Mutex
+}
+
+// NewAnkiExport creates dir with the media folder and overwrites the cards file.
+func NewAnkiExport(dir string, downloader Downloader) (*AnkiExport, error) {
+	if err := os.MkdirAll(filepath.Join(dir, ankiMediaDir), 0o750); err != nil {
+		return nil, fmt.Errorf("create export directory: %w", err)
+	}
+	file, err := os.Create(filepath.Join(dir, ankiCardsFile))
+	if err != nil {
+		return nil, fmt.Errorf("create cards file: %w", err)
+	}
+	for _, line := range ankiHeader {
+		if _, err = fmt.Fprintln(file, line); err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("write cards file: %w", err)
+		}
+	}
+	cards := csv.NewWriter(file)
+	cards.Comma = ankiCardsSeparator
+	return &AnkiExport{downloader: downloader, file: file, cards: cards, dir: dir}, nil
+}
+
+// Output writes the card of the result. A card is written without the media
+// failed to download, these failures are returned wrapping errMediaDownload.
+func (e *AnkiExport) Output(ctx context.Context, result api.Result) error {
+	return e.Prepare(ctx, result)()
+}
+
+// Prepare downloads the media of the card, the returned function writes the card.
+func (e *AnkiExport) Prepare(ctx context.Context, result api.Result) func() error {
+	front := []string{html.EscapeString(result.Word)}
+	sound, soundErr := e.media(ctx, result.SoundURL, defaultSoundExt)
+	if sound != "" {
+		front = append(front, fmt.Sprintf("[sound:%s]", sound))
+	}
+	var back []string
+	if result.Transcription != "" {
+		back = append(back, html.EscapeString(fmt.Sprintf("[%s]", result.Transcription)))
+	}
+	for _, translation := range exportTranslations(result) {
+		back = append(back, html.EscapeString(translation))
+	}
+	picture, pictureErr := e.media(ctx, firstPicture(result), defaultPictureExt)
+	if picture != "" {
+		back = append(back, fmt.Sprintf(`<img src="%s">`, html.EscapeString(picture)))
+	}
+
+	return func() error {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if err := e.cards.Write([]string{strings.Join(front, " "), strings.Join(back, "<br>")}); err != nil {
+			return fmt.Errorf("write card: %w", err)
+		}
+		e.cards.Flush()
+		if err := e.cards.Error(); err != nil {
+			return fmt.Errorf("write card: %w", err)
+		}
+		err := messagef(messages.GREEN, "Exported card: ['%s']\n", result.Word)
+		if mediaErr := errors.Join(soundErr, pictureErr); mediaErr != nil {
+			err = errors.Join(err, fmt.Errorf("%w: %w", errMediaDownload, mediaErr))
+		}
+		return err
+	}
+}
+
+// Close flushes and closes the cards file.
+func (e *AnkiExport) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.cards.Flush()
+	return errors.Join(e.cards.Error(), e.file.Close())
+}
+
+// exportTranslations returns the custom translations of the word if given,
+// otherwise the translations with their context.
+func exportTranslations(result api.Result) []string {
+	if len(result.AddWords) > 0 {
+		return result.AddWords
+	}
+	translations := make([]string, 0, len(result.Translate))
+	for _, word := range result.Translate {
+		if word.Context == "" {
+			translations = append(translations, word.Value)
+			continue
+		}
+		translations = append(translations, fmt.Sprintf("%s (%s)", word.Value, word.Context))
+	}
+	return translations
+}
+
+func firstPicture(result api.Result) string {
+	for _, word := range result.Translate {
+		if word.Picture != "" {
+			return word.Picture
+		}
+	}
+	return result.Picture
+}
+
+// media downloads the URL into the media folder and returns the file name.
+// A failed download leaves the card without the media.
+func (e *AnkiExport) media(ctx context.Context, rawURL string, defaultExt string) (string, error) {
+	if rawURL == "" {
+		return "", nil
+	}
+	name := mediaName(rawURL, defaultExt)
+	target := filepath.Join(e.dir, ankiMediaDir, name)
+	if _, err := os.Stat(target); err == nil {
+		return name, nil
+	}
+	filename, err := e.downloader.Download(ctx, rawURL)
+	if err != nil {
+		return "", fmt.Errorf("download %s: %w", rawURL, err)
+	}
+	defer func() {
+		if rErr := e.downloader.Remove(filename); rErr != nil && !errors.Is(rErr, os.ErrNotExist) {
+			slog.Warn("cannot remove downloaded file", "filename", filename, "error", rErr)
+		}
+	}()
+	if err = copyFile(filename, target); err != nil {
+		return "", fmt.Errorf("save %s: %w", rawURL, err)
+	}
+	return name, nil
+}
+
+// mediaName is a stable file name of the URL, so exports do not duplicate media.
+func mediaName(rawURL string, defaultExt string) string {
+	ext := defaultExt
+	if u, err := url.Parse(rawURL); err == nil && path.Ext(u.Path) != "" {
+		ext = strings.ToLower(path.Ext(u.Path))
+	}
+	sum := sha256.Sum256([]byte(rawURL))
+	return ankiMediaPrefix + hex.EncodeToString(sum[:])[:ankiMediaHashSize] + ext
+}
+
+func copyFile(source string, target string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		_ = out.Close()
+		_ = os.Remove(target)
+		return err
+	}
+	return out.Close()
+}
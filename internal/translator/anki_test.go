@@ -0,0 +1,109 @@
+package translator
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trezorg/lingualeo/internal/api"
+)
+
+func downloadedFile(t *testing.T, content string) string {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), "download")
+	require.NoError(t, os.WriteFile(filename, []byte(content), 0o600))
+	return filename
+}
+
+func readCards(t *testing.T, dir string) [][]string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, ankiCardsFile))
+	require.NoError(t, err)
+	lines := strings.SplitN(string(data), "\n", len(ankiHeader)+1)
+	require.Equal(t, ankiHeader, lines[:len(ankiHeader)])
+	reader := csv.NewReader(strings.NewReader(lines[len(ankiHeader)]))
+	reader.Comma = ankiCardsSeparator
+	cards, err := reader.ReadAll()
+	require.NoError(t, err)
+	return cards
+}
+
+func TestAnkiExportWritesCardsAndMedia(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "anki")
+	soundURL := "https://example.com/sound/hello.MP3"
+	pictureURL := "https://example.com/pictures/hello.png?size=2"
+	sound, picture := downloadedFile(t, "sound"), downloadedFile(t, "picture")
+
+	downloader := NewMock_Downloader(t)
+	downloader.EXPECT().Download(t.Context(), soundURL).Return(sound, nil).Once()
+	downloader.EXPECT().Download(t.Context(), pictureURL).Return(picture, nil).Once()
+	downloader.EXPECT().Download(t.Context(), "https://example.com/broken").Return("", errors.New("not found")).Once()
+	downloader.EXPECT().Remove(sound).Return(nil).Once()
+	downloader.EXPECT().Remove(picture).Return(nil).Once()
+
+	export, err := NewAnkiExport(dir, downloader)
+	require.NoError(t, err)
+	require.NoError(t, export.Output(t.Context(), api.Result{
+		Word:          "hello",
+		Transcription: "həˈləʊ",
+		SoundURL:      soundURL,
+		Picture:       "https://example.com/pictures/top.png",
+		Translate: []api.Word{
+			{Value: "привет", Context: "<greeting>"},
+			{Value: "алло", Picture: pictureURL},
+		},
+	}))
+	// The media of the same URLs are downloaded once.
+	require.NoError(t, export.Output(t.Context(), api.Result{
+		Word:      "hello again",
+		SoundURL:  soundURL,
+		Translate: []api.Word{{Value: "снова привет", Picture: pictureURL}},
+		AddWords:  []string{"привет ещё раз"},
+	}))
+	// The card is written without the media failed to download.
+	require.ErrorIs(t, export.Output(t.Context(), api.Result{
+		Word:      "broken",
+		SoundURL:  "https://example.com/broken",
+		Translate: []api.Word{{Value: "сломанный"}},
+	}), errMediaDownload)
+	require.NoError(t, export.Close())
+
+	soundName := mediaName(soundURL, defaultSoundExt)
+	pictureName := mediaName(pictureURL, defaultPictureExt)
+	require.True(t, strings.HasSuffix(soundName, ".mp3"))
+	require.True(t, strings.HasSuffix(pictureName, ".png"))
+	require.Equal(t, [][]string{
+		{"hello [sound:" + soundName + "]", `[həˈləʊ]<br>привет (&lt;greeting&gt;)<br>алло<br><img src="` + pictureName + `">`},
+		{"hello again [sound:" + soundName + "]", `привет ещё раз<br><img src="` + pictureName + `">`},
+		{"broken", "сломанный"},
+	}, readCards(t, dir))
+
+	content, err := os.ReadFile(filepath.Join(dir, ankiMediaDir, soundName))
+	require.NoError(t, err)
+	require.Equal(t, "sound", string(content))
+}
+
+func TestOutputDownloadsExportMediaWithoutConsoleLock(t *testing.T) {
+	soundURL := "https://example.com/sound/hello.mp3"
+	sound := downloadedFile(t, "sound")
+	downloader := NewMock_Downloader(t)
+	downloader.EXPECT().Download(t.Context(), soundURL).RunAndReturn(func(context.Context, string) (string, error) {
+		// Other workers can print while the media are downloaded.
+		require.True(t, console.TryLock())
+		console.Unlock()
+		return sound, nil
+	}).Once()
+	downloader.EXPECT().Remove(sound).Return(nil).Once()
+	export, err := NewAnkiExport(t.TempDir(), downloader)
+	require.NoError(t, err)
+
+	app := Lingualeo{Outputer: export}
+	require.NoError(t, app.output(t.Context(), api.Result{Word: "hello", SoundURL: soundURL}))
+	require.NoError(t, export.Close())
+}
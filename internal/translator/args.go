@@ -6,17 +6,24 @@ import (
 	"path/filepath"
 
 	"github.com/trezorg/lingualeo/internal/files"
+	"github.com/trezorg/lingualeo/internal/lang"
 	"github.com/trezorg/lingualeo/internal/validator"
 )
 
 var (
 	errNoWords                 = errors.New("there are no words to translate")
 	errAddCustomTranslation    = errors.New("custom translation requires exactly one word")
+	errEditTranslation         = errors.New("edit requires at least one translation")
 	errConfigFileMissing       = errors.New("config file is missing or invalid")
 	errEmailArgumentMissing    = errors.New("email argument is missing")
 	errEmailInvalid            = errors.New("email argument is invalid")
 	errPasswordArgumentMissing = errors.New("password argument is missing")
 	errPasswordPromptNonTTY    = errors.New("cannot prompt for password from non-terminal stdin")
+	errEndpointInvalid         = errors.New("api endpoint is invalid")
+	errRecordAndReplay         = errors.New("record and replay cannot be used together")
+	errMultipleInputs          = errors.New("words can be read from a single input")
+	errInputCommand            = errors.New("input is supported only by translate, add and export")
+	errNegativeFilter          = errors.New("translation filters cannot be negative")
 
 	// ErrHelpOrVersionShown is returned when --help or --version flag is passed.
 	// The caller should treat this as a successful exit (os.Exit(0)).
@@ -40,11 +47,55 @@ func (l *Lingualeo) checkArgs() error {
 	if err := validator.ValidateEmail(l.Email); err != nil {
 		return fmt.Errorf("%w: %w", errEmailInvalid, err)
 	}
-	if len(l.Password) == 0 {
+	if len(l.Password) == 0 && !l.Offline && l.Replay == "" {
 		return errPasswordArgumentMissing
 	}
-	if len(l.Words) == 0 {
+	if _, err := lang.ParsePair(l.SourceLanguage, l.TargetLanguage); err != nil {
+		return err
+	}
+	if l.Record != "" && l.Replay != "" {
+		return errRecordAndReplay
+	}
+	if l.InputFile != "" && l.Command != CommandTranslate && l.Command != CommandExport {
+		return errInputCommand
+	}
+	if format := l.OutputFormat; format != "" {
+		if err := format.Set(string(format)); err != nil {
+			return err
+		}
+	}
+	if order := l.Order; order != "" {
+		if err := order.Set(string(order)); err != nil {
+			return err
+		}
+	}
+	if summary := l.Summary; summary != "" {
+		if err := summary.Set(string(summary)); err != nil {
+			return err
+		}
+	}
+	if l.Template != "" && l.TemplateFile != "" {
+		return errTemplateAndFile
+	}
+	if l.usesTemplate() && !l.OutputFormat.isText() {
+		return errTemplateFormat
+	}
+	if l.Command == CommandExport && l.ExportFormat != AnkiFormat {
+		return fmt.Errorf("%w: %s", errExportFormat, l.ExportFormat)
+	}
+	if l.Top < 0 || l.MinVotes < 0 {
+		return errNegativeFilter
+	}
+	if l.SelectTranslations && l.InputFile == stdinInput {
+		return errSelectStdinInput
+	}
+	if len(l.Words) == 0 && l.InputFile == "" && l.Command.needsWords() {
 		return errNoWords
 	}
+	for _, endpoint := range []string{l.APIURL, l.AuthURL, l.TranslateURL, l.AddWordURL, l.DictionaryURL, l.SetWordsURL} {
+		if err := validator.ValidateURL(endpoint); err != nil {
+			return fmt.Errorf("%w: %s: %w", errEndpointInvalid, endpoint, err)
+		}
+	}
 	return nil
 }
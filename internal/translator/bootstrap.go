@@ -3,10 +3,16 @@ package translator
 import (
 	"errors"
 	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
 
 	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/cache"
 	"github.com/trezorg/lingualeo/internal/files"
 	"github.com/trezorg/lingualeo/internal/httpclient"
+	"github.com/trezorg/lingualeo/internal/messages"
 	"github.com/trezorg/lingualeo/internal/player"
 )
 
@@ -17,30 +23,130 @@ var (
 	errMissingDownloader = errors.New("downloader is required when downloading sound")
 )
 
+// cacheMiddleware opens the translation cache. Unless configured otherwise,
+// every account gets its own cache directory as translations carry dictionary flags.
+func cacheMiddleware(cfg *Config) (api.Middleware, error) {
+	dir := cfg.CacheDir
+	if dir == "" {
+		base, err := cache.DefaultDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(base, accountID(cfg.Email))
+	}
+	return cache.Middleware(dir,
+		cache.WithTTL(cfg.CacheTTL),
+		cache.WithMaxEntries(cfg.CacheMaxEntries),
+		cache.WithOffline(cfg.Offline),
+		cache.WithNamespace(cfg.languages().String()),
+	)
+}
+
+// cassette creates the HTTP traffic cassette for --record or --replay, or returns nil.
+func cassette(cfg *Config) (*httpclient.Cassette, error) {
+	redactor := httpclient.NewRedactor(cfg.DebugRedactHeaders, cfg.DebugRedactFields)
+	switch {
+	case cfg.Replay != "":
+		return httpclient.NewCassette(cfg.Replay, httpclient.CassetteReplay, redactor)
+	case cfg.Record != "":
+		return httpclient.NewCassette(cfg.Record, httpclient.CassetteRecord, redactor)
+	default:
+		return nil, nil
+	}
+}
+
 func Bootstrap(app *Lingualeo) error {
-	httpClient, err := httpclient.NewWithJar(
+	jar, err := httpclient.NewJar()
+	if err != nil {
+		return fmt.Errorf("create HTTP client: %w", err)
+	}
+	recording, err := cassette(&app.Config)
+	if err != nil {
+		return fmt.Errorf("create HTTP cassette: %w", err)
+	}
+	httpClient, err := httpclient.NewWithCookieJar(
 		httpclient.Config{
+			Limiter:             httpclient.NewLimiter(app.RateLimit, app.RateBurst),
+			MaxRetryAfter:       app.RetryAfterMax,
+			Cassette:            recording,
+			ProxyURL:            app.ProxyURL,
+			NoProxy:             app.NoProxy,
+			CAFile:              app.CAFile,
+			CertFile:            app.ClientCert,
+			KeyFile:             app.ClientKey,
+			DialTimeout:         app.DialTimeout,
+			TLSHandshakeTimeout: app.TLSHandshakeTimeout,
 			MaxIdleConns:        app.MaxIdleConns,
 			MaxIdleConnsPerHost: app.MaxIdleConnsPerHost,
 		},
 		app.MaxRedirects,
+		jar,
 	)
 	if err != nil {
 		return fmt.Errorf("create HTTP client: %w", err)
 	}
+	// A saved session would leave authentication out of recordings and replays.
+	if !app.NoSession && recording == nil {
+		if app.session, err = newSession(jar, app.SessionFile, app.Email); err != nil {
+			return fmt.Errorf("restore session: %w", err)
+		}
+	}
 
-	app.Client = api.New(app.Email, app.Password, app.Debug, app.APIClientConfig(), httpClient)
-	app.Downloader = files.New(httpClient)
+	// Middlewares given with WithMiddleware are the outermost ones.
+	middlewares := append(slices.Clone(app.middlewares), api.Logging(nil))
+	if app.Cache || app.Offline {
+		cached, cErr := cacheMiddleware(&app.Config)
+		if cErr != nil {
+			return fmt.Errorf("create translation cache: %w", cErr)
+		}
+		middlewares = append(middlewares, cached)
+	}
+	apiConfig := app.APIClientConfig()
+	app.Client = api.Chain(
+		api.New(app.Email, app.Password, app.Debug, apiConfig, httpClient),
+		middlewares...,
+	)
+	var downloaderOpts []files.Option
+	if app.Debug {
+		downloaderOpts = append(downloaderOpts, files.WithDebug(apiConfig.Redactor))
+	}
+	app.Downloader = files.New(httpClient, downloaderOpts...)
+
+	if app.SelectTranslations && app.Selector == nil && (app.Add || app.Command == CommandRepl) {
+		selector, sErr := newTerminalSelector()
+		if sErr != nil {
+			slog.Warn("adding all translations", "error", sErr)
+		} else {
+			app.Selector = selector
+		}
+	}
 
 	if app.Sound {
 		app.Pronouncer = player.New(app.Player, player.WithShutdownTimeout(app.PlayerShutdownTimeout))
 	}
 
-	outputer, err := NewOutputer(app.Visualise, app.VisualiseType)
+	switch {
+	case app.Command == CommandExport:
+		app.Outputer, err = NewAnkiExport(app.ExportDir, app.Downloader)
+	case app.usesTemplate():
+		text, tErr := app.templateText()
+		if tErr != nil {
+			return tErr
+		}
+		app.Outputer, err = NewTemplateOutput(text, os.Stdout)
+	case app.OutputFormat == TableFormat:
+		app.Outputer = NewTableOutput(os.Stdout, app.Top)
+	case app.OutputFormat.machineReadable():
+		// Keep stdout for the results only.
+		messages.SetOutput(os.Stderr)
+		app.Outputer, err = NewRecordOutput(app.OutputFormat, os.Stdout)
+	default:
+		app.Outputer, err = outputer(app.Visualise, app.VisualiseType, httpClient)
+	}
 	if err != nil {
 		return fmt.Errorf("create outputer: %w", err)
 	}
-	app.Outputer = outputer
+	app.httpClient = httpClient
 
 	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/cache"
 )
 
 func TestBootstrapInjectsRuntimeDependencies(t *testing.T) {
@@ -35,6 +36,46 @@ func TestBootstrapInjectsPronouncerWhenSoundEnabled(t *testing.T) {
 	require.NoError(t, app.Validate())
 }
 
+func TestBootstrapWrapsClientWithCache(t *testing.T) {
+	t.Parallel()
+
+	app := Lingualeo{Config: Config{Email: "user@example.com", Password: "secret", Offline: true, CacheDir: t.TempDir()}}
+
+	err := Bootstrap(&app)
+	require.NoError(t, err)
+	require.ErrorIs(t, app.TranslateWord(t.Context(), "hello").Error, cache.ErrOfflineMiss)
+}
+
+func TestBootstrapAppliesMiddlewares(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	middleware := func(name string) api.Middleware {
+		return func(next api.Client) api.Client {
+			return recordingClient{Client: next, name: name, calls: &calls}
+		}
+	}
+	app := Lingualeo{Config: Config{Email: "user@example.com", Password: "secret", Offline: true, CacheDir: t.TempDir()}}
+	require.NoError(t, WithMiddleware(middleware("outer"), middleware("inner"))(&app))
+
+	require.NoError(t, Bootstrap(&app))
+	res := app.TranslateWord(t.Context(), "hello")
+	require.ErrorIs(t, res.Error, cache.ErrOfflineMiss)
+	require.Equal(t, []string{"outer", "inner"}, calls)
+}
+
+// recordingClient records its name on every translation.
+type recordingClient struct {
+	api.Client
+	calls *[]string
+	name  string
+}
+
+func (c recordingClient) TranslateWord(ctx context.Context, word string) api.OperationResult {
+	*c.calls = append(*c.calls, c.name)
+	return c.Client.TranslateWord(ctx, word)
+}
+
 func TestBootstrapReturnsOutputerError(t *testing.T) {
 	t.Parallel()
 
@@ -112,6 +153,18 @@ func (apiMockClient) Auth(_ context.Context) error {
 	return nil
 }
 
+func (apiMockClient) ListDictionary(_ context.Context, _ api.DictionaryQuery) (api.DictionaryPage, error) {
+	return api.DictionaryPage{}, nil
+}
+
+func (apiMockClient) DeleteWord(_ context.Context, word string) api.OperationResult {
+	return api.OperationResult{Result: api.Result{Word: word}}
+}
+
+func (apiMockClient) UpdateTranslation(_ context.Context, word string, translates []string) api.OperationResult {
+	return api.OperationResult{Result: api.Result{Word: word, AddWords: translates}}
+}
+
 type noopDownloader struct{}
 
 func (noopDownloader) Download(_ context.Context, _ string) (string, error) {
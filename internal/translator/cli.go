@@ -1,9 +1,15 @@
 package translator
 
 import (
+	"cmp"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/lang"
 	"github.com/trezorg/lingualeo/internal/slice"
 
 	"github.com/urfave/cli/v2"
@@ -48,17 +54,43 @@ func prepareTranslator(version string) (Lingualeo, error) {
 
 func buildDefaultCommand(args *Lingualeo, translate *cli.StringSlice) func(*cli.Context) error {
 	return func(c *cli.Context) error {
-		if c.NArg() == 0 {
+		if c.NArg() == 0 && args.InputFile == "" {
 			if err := cli.ShowAppHelp(c); err != nil {
 				return fmt.Errorf("%w: %w", errNoWords, err)
 			}
 			return errNoWords
 		}
 
-		args.Words = slice.Unique(c.Args().Slice())
+		words := c.Args().Slice()
+		// A lone "-" argument reads words from stdin.
+		if slices.Contains(words, stdinInput) {
+			if args.InputFile != "" && args.InputFile != stdinInput {
+				return errMultipleInputs
+			}
+			args.InputFile = stdinInput
+			words = slices.DeleteFunc(words, func(word string) bool { return word == stdinInput })
+		}
+		args.Words = slice.Unique(words)
 		args.Translation = slice.Unique(translate.Value())
 		args.VisualiseType = *c.Generic("visualize-type").(*VisualiseType)
-		if args.Add && len(args.Translation) > 0 && len(args.Words) > 1 {
+		if args.Add && len(args.Translation) > 0 && (len(args.Words) > 1 || args.InputFile != "") {
+			return errAddCustomTranslation
+		}
+
+		return nil
+	}
+}
+
+func buildEditCommand(args *Lingualeo, defaultCommand func(*cli.Context) error) func(*cli.Context) error {
+	return func(c *cli.Context) error {
+		args.Command = CommandEdit
+		if err := defaultCommand(c); err != nil {
+			return err
+		}
+		if len(args.Translation) == 0 {
+			return errEditTranslation
+		}
+		if len(args.Words) > 1 {
 			return errAddCustomTranslation
 		}
 
@@ -66,6 +98,114 @@ func buildDefaultCommand(args *Lingualeo, translate *cli.StringSlice) func(*cli.
 	}
 }
 
+func buildListCommand(args *Lingualeo) func(*cli.Context) error {
+	return func(c *cli.Context) error {
+		status, err := api.ParseLearningStatus(c.String("status"))
+		if err != nil {
+			return err
+		}
+		args.Command = CommandList
+		args.VisualiseType = *c.Generic("visualize-type").(*VisualiseType)
+		args.AllPages = c.Bool("all")
+		args.DictionaryQuery = api.DictionaryQuery{
+			Status:  status,
+			Page:    c.Int("page"),
+			PerPage: c.Int("per-page"),
+		}
+		if after := c.Timestamp("added-after"); after != nil {
+			args.DictionaryQuery.AddedAfter = *after
+		}
+		if before := c.Timestamp("added-before"); before != nil {
+			args.DictionaryQuery.AddedBefore = *before
+		}
+
+		return nil
+	}
+}
+
+func listCommand(args *Lingualeo) *cli.Command {
+	return &cli.Command{
+		Name:    "list",
+		Aliases: []string{"ls"},
+		Usage:   "List words from lingualeo dictionary",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "status",
+				Usage: "Learning status filter. Allowed values: new, learning, learned",
+			},
+			&cli.TimestampFlag{
+				Name:   "added-after",
+				Layout: time.DateOnly,
+				Usage:  "Only words added on or after the date (YYYY-MM-DD)",
+			},
+			&cli.TimestampFlag{
+				Name:   "added-before",
+				Layout: time.DateOnly,
+				Usage:  "Only words added on or before the date (YYYY-MM-DD)",
+			},
+			&cli.IntFlag{
+				Name:  "page",
+				Value: 1,
+				Usage: "Page to show",
+			},
+			&cli.IntFlag{
+				Name:  "per-page",
+				Value: defaultDictionaryPerPage,
+				Usage: "Words per page",
+			},
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: "Show all pages starting from --page",
+			},
+		},
+		Action: buildListCommand(args),
+	}
+}
+
+func exportCommand(args *Lingualeo, defaultCommand func(*cli.Context) error) *cli.Command {
+	return &cli.Command{
+		Name:      "export",
+		Usage:     "Export translated words as flashcards with pronunciations and pictures",
+		ArgsUsage: "Multiple words can be supplied",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Usage:   "Export format. Allowed values: " + AnkiFormat,
+			},
+			&cli.StringFlag{
+				Name:  "dir",
+				Usage: "Directory for the cards file and the media folder (default: " + defaultExportDir + ")",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			args.Command = CommandExport
+			args.ExportFormat = cmp.Or(c.String("format"), args.ExportFormat)
+			args.ExportDir = cmp.Or(c.String("dir"), args.ExportDir)
+			return defaultCommand(c)
+		},
+	}
+}
+
+func replCommand(args *Lingualeo) *cli.Command {
+	return &cli.Command{
+		Name:  "repl",
+		Usage: "Translate words entered interactively line by line. Type :help for commands",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "history-file",
+				Usage: "File to keep the entered lines between sessions (default: $XDG_STATE_HOME/lingualeo/history)",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			args.Command = CommandRepl
+			args.VisualiseType = *c.Generic("visualize-type").(*VisualiseType)
+			args.HistoryFile = cmp.Or(c.String("history-file"), args.HistoryFile)
+			return nil
+		},
+	}
+}
+
 func newLingualeoApp(version string, args *Lingualeo, translate *cli.StringSlice, defaultCommand func(*cli.Context) error) *cli.App {
 	app := cli.NewApp()
 	app.Version = version
@@ -126,12 +266,47 @@ func newLingualeoApp(version string, args *Lingualeo, translate *cli.StringSlice
 					Usage:       "Custom translation: lingualeo add -t word1 -t word2 word",
 					Destination: translate,
 				},
+				&cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "Show which translations would be added or skipped as existing without changing the dictionary",
+				},
+				&cli.BoolFlag{
+					Name:  "select",
+					Usage: "Choose translations to add from a numbered list. Without a terminal all translations are added",
+				},
 			},
 			Action: func(c *cli.Context) error {
 				args.Add = true
+				args.SelectTranslations = args.SelectTranslations || c.Bool("select")
+				args.DryRun = args.DryRun || c.Bool("dry-run")
 				return defaultCommand(c)
 			},
 		},
+		{
+			Name:    "delete",
+			Aliases: []string{"rm"},
+			Usage:   "Delete words from lingualeo dictionary",
+			Action: func(c *cli.Context) error {
+				args.Command = CommandDelete
+				return defaultCommand(c)
+			},
+		},
+		{
+			Name:  "edit",
+			Usage: "Replace translations of a word in lingualeo dictionary",
+			Flags: []cli.Flag{
+				&cli.StringSliceFlag{
+					Name:        "translate",
+					Aliases:     []string{"t"},
+					Usage:       "New translation: lingualeo edit -t word1 -t word2 word",
+					Destination: translate,
+				},
+			},
+			Action: buildEditCommand(args, defaultCommand),
+		},
+		listCommand(args),
+		replCommand(args),
+		exportCommand(args, defaultCommand),
 	}
 
 	return app
@@ -140,6 +315,9 @@ func newLingualeoApp(version string, args *Lingualeo, translate *cli.StringSlice
 func buildLingualeoFlags(args *Lingualeo) []cli.Flag {
 	base := baseLingualeoFlags(args)
 	base = append(base, httpAndRetryFlags(args)...)
+	base = append(base, endpointFlags(args)...)
+	base = append(base, cacheFlags(args)...)
+	base = append(base, inputFlag(args))
 	base = append(base, genericLingualeoFlags(args)...)
 
 	return append(base, boolLingualeoFlags(args)...)
@@ -190,6 +368,38 @@ func baseLingualeoFlags(args *Lingualeo) []cli.Flag {
 			Usage:       "Log level",
 			Destination: &args.LogLevel,
 		},
+		&cli.StringFlag{
+			Name:        "source-language",
+			Aliases:     []string{"sl"},
+			Value:       args.SourceLanguage,
+			Usage:       fmt.Sprintf("Learned language of translated words. Allowed values: %s", strings.Join(lang.Codes(), ", ")),
+			Destination: &args.SourceLanguage,
+		},
+		&cli.StringFlag{
+			Name:        "target-language",
+			Aliases:     []string{"tl"},
+			Value:       args.TargetLanguage,
+			Usage:       fmt.Sprintf("Native language of translations. Allowed values: %s", strings.Join(lang.Codes(), ", ")),
+			Destination: &args.TargetLanguage,
+		},
+		&cli.StringFlag{
+			Name:        "template",
+			Value:       args.Template,
+			Usage:       "Go text/template printing every translation result, e.g. '{{ .Word }}: {{ .Translate | top 3 | values | join \", \" }}'",
+			Destination: &args.Template,
+		},
+		&cli.StringFlag{
+			Name:        "template-file",
+			Value:       args.TemplateFile,
+			Usage:       "File with the output template, see --template",
+			Destination: &args.TemplateFile,
+		},
+		&cli.StringFlag{
+			Name:        "session-file",
+			Value:       args.SessionFile,
+			Usage:       "File to keep the authenticated session between runs (default: $XDG_STATE_HOME/lingualeo/session-<account hash>.json)",
+			Destination: &args.SessionFile,
+		},
 		&cli.DurationFlag{
 			Name:        "timeout",
 			Aliases:     []string{"t"},
@@ -197,6 +407,18 @@ func baseLingualeoFlags(args *Lingualeo) []cli.Flag {
 			Usage:       "HTTP request timeout (e.g., 10s, 30s, 1m)",
 			Destination: &args.RequestTimeout,
 		},
+		&cli.IntFlag{
+			Name:        "top",
+			Value:       args.Top,
+			Usage:       "Show and add only the N most voted translations. 0 means all",
+			Destination: &args.Top,
+		},
+		&cli.IntFlag{
+			Name:        "min-votes",
+			Value:       args.MinVotes,
+			Usage:       "Show and add only translations with at least V votes",
+			Destination: &args.MinVotes,
+		},
 		&cli.IntFlag{
 			Name:        "workers",
 			Value:       args.Workers,
@@ -244,9 +466,185 @@ func httpAndRetryFlags(args *Lingualeo) []cli.Flag {
 			Usage:       "Maximum wait between retry attempts",
 			Destination: &args.RetryMaxWait,
 		},
+		&cli.DurationFlag{
+			Name:        "retry-after-max",
+			Value:       args.RetryAfterMax,
+			Usage:       "Maximum wait requested by the server with Retry-After. Requests asking for longer waits fail without retrying",
+			Destination: &args.RetryAfterMax,
+		},
+		&cli.Float64Flag{
+			Name:        "rate-limit",
+			Value:       args.RateLimit,
+			Usage:       "Maximum HTTP requests per second for API calls and downloads. 0 means no limit",
+			Destination: &args.RateLimit,
+		},
+		&cli.IntFlag{
+			Name:        "rate-burst",
+			Value:       args.RateBurst,
+			Usage:       "Maximum burst of HTTP requests allowed by --rate-limit",
+			Destination: &args.RateBurst,
+		},
+		&cli.StringFlag{
+			Name:        "proxy",
+			Value:       args.ProxyURL,
+			Usage:       "HTTP, HTTPS or SOCKS5 proxy URL, e.g. socks5://localhost:1080. Defaults to HTTP_PROXY/HTTPS_PROXY",
+			Destination: &args.ProxyURL,
+		},
+		&cli.GenericFlag{
+			Name:  "no-proxy",
+			Usage: "Host, domain or CIDR reached without the proxy. Can be repeated. Defaults to NO_PROXY",
+			Value: (*stringList)(&args.NoProxy),
+		},
+		&cli.StringFlag{
+			Name:        "ca-file",
+			Value:       args.CAFile,
+			Usage:       "PEM CA bundle trusted in addition to the system certificates",
+			Destination: &args.CAFile,
+		},
+		&cli.StringFlag{
+			Name:        "client-cert",
+			Value:       args.ClientCert,
+			Usage:       "PEM client certificate for TLS connections",
+			Destination: &args.ClientCert,
+		},
+		&cli.StringFlag{
+			Name:        "client-key",
+			Value:       args.ClientKey,
+			Usage:       "PEM key of the client certificate",
+			Destination: &args.ClientKey,
+		},
+		&cli.DurationFlag{
+			Name:        "dial-timeout",
+			Value:       args.DialTimeout,
+			Usage:       "Timeout for establishing TCP connections",
+			Destination: &args.DialTimeout,
+		},
+		&cli.DurationFlag{
+			Name:        "tls-handshake-timeout",
+			Value:       args.TLSHandshakeTimeout,
+			Usage:       "Timeout for TLS handshakes",
+			Destination: &args.TLSHandshakeTimeout,
+		},
+		&cli.StringFlag{
+			Name:        "record",
+			Value:       args.Record,
+			Usage:       "Record redacted HTTP traffic into a cassette file",
+			Destination: &args.Record,
+		},
+		&cli.StringFlag{
+			Name:        "replay",
+			Value:       args.Replay,
+			Usage:       "Replay HTTP traffic from a cassette file recorded with --record without network access",
+			Destination: &args.Replay,
+		},
 	}
 }
 
+func endpointFlags(args *Lingualeo) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "api-url",
+			Value:       args.APIURL,
+			Usage:       "Base URL serving all Lingualeo API endpoints, e.g. a mirror or a local stand-in server",
+			EnvVars:     []string{"LINGUALEO_API_URL"},
+			Destination: &args.APIURL,
+		},
+		&cli.StringFlag{
+			Name:        "auth-url",
+			Value:       args.AuthURL,
+			Usage:       "Authentication endpoint URL. Overrides --api-url",
+			Destination: &args.AuthURL,
+		},
+		&cli.StringFlag{
+			Name:        "translate-url",
+			Value:       args.TranslateURL,
+			Usage:       "Translation endpoint URL. Overrides --api-url",
+			Destination: &args.TranslateURL,
+		},
+		&cli.StringFlag{
+			Name:        "add-word-url",
+			Value:       args.AddWordURL,
+			Usage:       "Add word endpoint URL. Overrides --api-url",
+			Destination: &args.AddWordURL,
+		},
+		&cli.StringFlag{
+			Name:        "dictionary-url",
+			Value:       args.DictionaryURL,
+			Usage:       "Dictionary listing endpoint URL. Overrides --api-url",
+			Destination: &args.DictionaryURL,
+		},
+		&cli.StringFlag{
+			Name:        "set-words-url",
+			Value:       args.SetWordsURL,
+			Usage:       "Dictionary delete/edit endpoint URL. Overrides --api-url",
+			Destination: &args.SetWordsURL,
+		},
+	}
+}
+
+func inputFlag(args *Lingualeo) cli.Flag {
+	return &cli.StringFlag{
+		Name:        "input",
+		Aliases:     []string{"i"},
+		Usage:       "File with a word or phrase per line, optionally followed by tab separated translations, for translate, add and export. \"-\" reads stdin",
+		Destination: &args.InputFile,
+	}
+}
+
+func cacheFlags(args *Lingualeo) []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:        "cache",
+			Usage:       "Cache translations on disk",
+			Value:       args.Cache,
+			Destination: &args.Cache,
+		},
+		&cli.StringFlag{
+			Name:        "cache-dir",
+			Value:       args.CacheDir,
+			Usage:       "Translation cache directory (default: per account under the user cache directory)",
+			Destination: &args.CacheDir,
+		},
+		&cli.DurationFlag{
+			Name:        "cache-ttl",
+			Value:       args.CacheTTL,
+			Usage:       "How long cached translations stay valid",
+			Destination: &args.CacheTTL,
+		},
+		&cli.IntFlag{
+			Name:        "cache-max-entries",
+			Value:       args.CacheMaxEntries,
+			Usage:       "Maximum number of cached translations",
+			Destination: &args.CacheMaxEntries,
+		},
+		&cli.BoolFlag{
+			Name:        "offline",
+			Usage:       "Answer only from the translation cache without calling the API",
+			Value:       args.Offline,
+			Destination: &args.Offline,
+		},
+	}
+}
+
+// stringList is a repeatable flag value appending to the list loaded from the config file.
+type stringList []string
+
+func (l *stringList) Set(value string) error {
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+func (l *stringList) String() string {
+	if l == nil {
+		return ""
+	}
+	return strings.Join(*l, ",")
+}
+
 func genericLingualeoFlags(args *Lingualeo) []cli.Flag {
 	return []cli.Flag{
 		&cli.GenericFlag{
@@ -255,6 +653,32 @@ func genericLingualeoFlags(args *Lingualeo) []cli.Flag {
 			Usage:   "Open picture either with default xdg-open or terminal graphic protocol. Allowed values: default, term",
 			Value:   new(args.VisualiseType),
 		},
+		&cli.GenericFlag{
+			Name:    "output",
+			Aliases: []string{"o"},
+			Usage:   fmt.Sprintf("Output format of translations. Allowed values: %v. Messages go to stderr with machine-readable formats", OutputFormats),
+			Value:   &args.OutputFormat,
+		},
+		&cli.GenericFlag{
+			Name:  "order",
+			Usage: fmt.Sprintf("Order of translated words. Allowed values: %v. Auto keeps the input order for up to %d words", OutputOrders, orderedOutputLimit),
+			Value: &args.Order,
+		},
+		&cli.GenericFlag{
+			Name:  "summary",
+			Usage: fmt.Sprintf("Summary of translated, added and played words printed at the end. Allowed values: %v", SummaryFormats),
+			Value: &args.Summary,
+		},
+		&cli.GenericFlag{
+			Name:  "redact-header",
+			Usage: "Additional header to redact from debug dumps. Can be repeated",
+			Value: (*stringList)(&args.DebugRedactHeaders),
+		},
+		&cli.GenericFlag{
+			Name:  "redact-field",
+			Usage: "Additional JSON field or query parameter to redact from debug dumps. Can be repeated",
+			Value: (*stringList)(&args.DebugRedactFields),
+		},
 	}
 }
 
@@ -295,10 +719,22 @@ func boolLingualeoFlags(args *Lingualeo) []cli.Flag {
 			Value:       args.LogPrettyPrint,
 			Destination: &args.LogPrettyPrint,
 		},
+		&cli.BoolFlag{
+			Name:        "no-session",
+			Usage:       "Do not reuse or save the authenticated session, authenticate on every run",
+			Value:       args.NoSession,
+			Destination: &args.NoSession,
+		},
+		&cli.BoolFlag{
+			Name:        "skip-existing",
+			Usage:       "Skip words already in the dictionary: do not show, pronounce or add them",
+			Value:       args.SkipExisting,
+			Destination: &args.SkipExisting,
+		},
 		&cli.BoolFlag{
 			Name:        "reverse-translate",
 			Aliases:     []string{"rt"},
-			Usage:       "Reverse translate russian words",
+			Usage:       "Translate back the translations of words given in the target language",
 			Value:       args.ReverseTranslate,
 			Destination: &args.ReverseTranslate,
 		},
@@ -0,0 +1,71 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/trezorg/lingualeo/internal/messages"
+)
+
+// Command is the action requested on the command line.
+type Command int
+
+const (
+	// CommandTranslate translates words and optionally adds them into the dictionary.
+	CommandTranslate Command = iota
+	// CommandList lists words from the user's dictionary.
+	CommandList
+	// CommandDelete deletes words from the user's dictionary.
+	CommandDelete
+	// CommandEdit replaces translations of a word in the user's dictionary.
+	CommandEdit
+	// CommandRepl translates words read interactively line by line.
+	CommandRepl
+	// CommandExport translates words into cards for flashcard applications.
+	CommandExport
+)
+
+// needsWords reports whether the command operates on words given by the user.
+func (c Command) needsWords() bool {
+	return c != CommandList && c != CommandRepl
+}
+
+// Run executes the parsed command and completes the output.
+// Commands working on the given words collect their outcomes into
+// the Report and print its Summary.
+func (l *Lingualeo) Run(ctx context.Context) error {
+	if l.Command.needsWords() {
+		l.report = NewReport()
+	}
+	err := l.run(ctx)
+	if closer, ok := l.Outputer.(io.Closer); ok {
+		err = errors.Join(err, closer.Close())
+	}
+	if l.report != nil {
+		err = errors.Join(err, l.report.Print(messages.Output(), l.Summary))
+	}
+	return err
+}
+
+// Report returns the outcomes of words of the last Run, nil for commands
+// not reporting them.
+func (l *Lingualeo) Report() *Report {
+	return l.report
+}
+
+func (l *Lingualeo) run(ctx context.Context) error {
+	switch l.Command {
+	case CommandList:
+		return l.ShowDictionary(ctx)
+	case CommandDelete:
+		l.DeleteFromDictionary(ctx)
+	case CommandEdit:
+		l.EditInDictionary(ctx)
+	case CommandTranslate, CommandExport:
+		return l.TranslateWithReverse(ctx)
+	case CommandRepl:
+		return l.Repl(ctx)
+	}
+	return nil
+}
@@ -5,6 +5,9 @@ import (
 	"time"
 
 	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/cache"
+	"github.com/trezorg/lingualeo/internal/httpclient"
+	"github.com/trezorg/lingualeo/internal/lang"
 )
 
 // Config holds all serializable configuration for Lingualeo.
@@ -21,6 +24,9 @@ type Config struct {
 	LogLevel       string `yaml:"log_level" json:"log_level" toml:"log_level"`
 	LogPrettyPrint bool   `yaml:"log_pretty_print" json:"log_pretty_print" toml:"log_pretty_print"`
 	Debug          bool   `yaml:"debug" json:"debug" toml:"debug"`
+	// Headers and JSON fields redacted from debug dumps in addition to the default ones
+	DebugRedactHeaders []string `yaml:"debug_redact_headers" json:"debug_redact_headers" toml:"debug_redact_headers"`
+	DebugRedactFields  []string `yaml:"debug_redact_fields" json:"debug_redact_fields" toml:"debug_redact_fields"`
 
 	// Behavior toggles
 	Add               bool          `yaml:"add" json:"add" toml:"add"`
@@ -30,6 +36,44 @@ type Config struct {
 	DownloadSoundFile bool          `yaml:"download" json:"download" toml:"download"`
 	ReverseTranslate  bool          `yaml:"reverse_translate" json:"reverse_translate" toml:"reverse_translate"`
 	PromptPassword    bool          `yaml:"prompt_password" json:"prompt_password" toml:"prompt_password"`
+	// Translation filters applied to both output and added translations, zero means no limit
+	Top          int  `yaml:"top" json:"top" toml:"top"`
+	MinVotes     int  `yaml:"min_votes" json:"min_votes" toml:"min_votes"`
+	SkipExisting bool `yaml:"skip_existing" json:"skip_existing" toml:"skip_existing"`
+	// Show the translations add would send without changing the dictionary
+	DryRun bool `yaml:"dry_run" json:"dry_run" toml:"dry_run"`
+	// Output format of translations, see OutputFormats
+	OutputFormat OutputFormat `yaml:"output" json:"output" toml:"output"`
+	// Order of the results: auto, input or completion, see OutputOrder
+	Order OutputOrder `yaml:"order" json:"order" toml:"order"`
+	// Summary of the run printed at the end: none, text or json, see Report
+	Summary SummaryFormat `yaml:"summary" json:"summary" toml:"summary"`
+	// Output template, see TemplateOutput
+	Template     string `yaml:"template" json:"template" toml:"template"`
+	TemplateFile string `yaml:"template_file" json:"template_file" toml:"template_file"`
+	// Export of translated words, see AnkiExport
+	ExportFormat string `yaml:"export_format" json:"export_format" toml:"export_format"`
+	ExportDir    string `yaml:"export_dir" json:"export_dir" toml:"export_dir"`
+	// Select translations to add interactively instead of adding all of them
+	SelectTranslations bool `yaml:"select_translations" json:"select_translations" toml:"select_translations"`
+
+	// Languages: the learned one and the native one translations are given in
+	SourceLanguage string `yaml:"source_language" json:"source_language" toml:"source_language"`
+	TargetLanguage string `yaml:"target_language" json:"target_language" toml:"target_language"`
+
+	// Session persistence
+	SessionFile string `yaml:"session_file" json:"session_file" toml:"session_file"`
+	NoSession   bool   `yaml:"no_session" json:"no_session" toml:"no_session"`
+
+	// Interactive mode line history
+	HistoryFile string `yaml:"history_file" json:"history_file" toml:"history_file"`
+
+	// Translation cache
+	Cache           bool          `yaml:"cache" json:"cache" toml:"cache"`
+	CacheDir        string        `yaml:"cache_dir" json:"cache_dir" toml:"cache_dir"`
+	CacheTTL        time.Duration `yaml:"cache_ttl" json:"cache_ttl" toml:"cache_ttl"`
+	CacheMaxEntries int           `yaml:"cache_max_entries" json:"cache_max_entries" toml:"cache_max_entries"`
+	Offline         bool          `yaml:"offline" json:"offline" toml:"offline"`
 
 	// Concurrency
 	Workers int `yaml:"workers" json:"workers" toml:"workers"`
@@ -42,6 +86,32 @@ type Config struct {
 	RetryMaxAttempts    int           `yaml:"retry_max_attempts" json:"retry_max_attempts" toml:"retry_max_attempts"`
 	RetryInitialWait    time.Duration `yaml:"retry_initial_wait" json:"retry_initial_wait" toml:"retry_initial_wait"`
 	RetryMaxWait        time.Duration `yaml:"retry_max_wait" json:"retry_max_wait" toml:"retry_max_wait"`
+	RetryAfterMax       time.Duration `yaml:"retry_after_max" json:"retry_after_max" toml:"retry_after_max"`
+
+	// Proxy and TLS settings shared by API calls, downloads and the terminal visualizer
+	ProxyURL            string        `yaml:"proxy_url" json:"proxy_url" toml:"proxy_url"`
+	NoProxy             []string      `yaml:"no_proxy" json:"no_proxy" toml:"no_proxy"`
+	CAFile              string        `yaml:"ca_file" json:"ca_file" toml:"ca_file"`
+	ClientCert          string        `yaml:"client_cert" json:"client_cert" toml:"client_cert"`
+	ClientKey           string        `yaml:"client_key" json:"client_key" toml:"client_key"`
+	DialTimeout         time.Duration `yaml:"dial_timeout" json:"dial_timeout" toml:"dial_timeout"`
+	TLSHandshakeTimeout time.Duration `yaml:"tls_handshake_timeout" json:"tls_handshake_timeout" toml:"tls_handshake_timeout"`
+
+	// HTTP traffic cassette, see httpclient.Cassette
+	Record string `yaml:"record" json:"record" toml:"record"`
+	Replay string `yaml:"replay" json:"replay" toml:"replay"`
+
+	// Rate limiting shared by API calls and downloads
+	RateLimit float64 `yaml:"rate_limit" json:"rate_limit" toml:"rate_limit"`
+	RateBurst int     `yaml:"rate_burst" json:"rate_burst" toml:"rate_burst"`
+
+	// API endpoints
+	APIURL        string `yaml:"api_url" json:"api_url" toml:"api_url"`
+	AuthURL       string `yaml:"auth_url" json:"auth_url" toml:"auth_url"`
+	TranslateURL  string `yaml:"translate_url" json:"translate_url" toml:"translate_url"`
+	AddWordURL    string `yaml:"add_word_url" json:"add_word_url" toml:"add_word_url"`
+	DictionaryURL string `yaml:"dictionary_url" json:"dictionary_url" toml:"dictionary_url"`
+	SetWordsURL   string `yaml:"set_words_url" json:"set_words_url" toml:"set_words_url"`
 }
 
 const defaultLogLevel = "INFO"
@@ -62,14 +132,51 @@ func (c *Config) APIClientConfig() api.Config {
 			MaxAttempts: c.RetryMaxAttempts,
 			InitialWait: c.RetryInitialWait,
 			MaxWait:     c.RetryMaxWait,
+
+			MaxRetryAfter: c.RetryAfterMax,
 		},
+		Endpoints: c.endpoints(),
+		Languages: c.languages(),
+		Redactor:  httpclient.NewRedactor(c.DebugRedactHeaders, c.DebugRedactFields),
+	}
+}
+
+// languages returns the configured language pair. Invalid languages are
+// rejected by checkArgs, here they fall back to lang.DefaultPair.
+func (c *Config) languages() lang.Pair {
+	pair, err := lang.ParsePair(c.SourceLanguage, c.TargetLanguage)
+	if err != nil {
+		return lang.DefaultPair
 	}
+	return pair
+}
+
+// endpoints builds API endpoints from the base URL and per-endpoint overrides.
+func (c *Config) endpoints() api.Endpoints {
+	endpoints := api.DefaultEndpoints()
+	if c.APIURL != "" {
+		endpoints = api.EndpointsFromBaseURL(c.APIURL)
+	}
+	endpoints.Auth = cmp.Or(c.AuthURL, endpoints.Auth)
+	endpoints.Translate = cmp.Or(c.TranslateURL, endpoints.Translate)
+	endpoints.AddWord = cmp.Or(c.AddWordURL, endpoints.AddWord)
+	endpoints.Dictionary = cmp.Or(c.DictionaryURL, endpoints.Dictionary)
+	endpoints.SetWords = cmp.Or(c.SetWordsURL, endpoints.SetWords)
+
+	return endpoints
 }
 
 func (c *Config) ApplyDefaults() {
 	defaults := api.DefaultConfig()
 	c.LogLevel = cmp.Or(c.LogLevel, defaultLogLevel)
 	c.Workers = cmp.Or(c.Workers, defaultWorkers)
+	c.SourceLanguage = cmp.Or(c.SourceLanguage, lang.DefaultPair.Source.Code)
+	c.TargetLanguage = cmp.Or(c.TargetLanguage, lang.DefaultPair.Target.Code)
+	c.OutputFormat = cmp.Or(c.OutputFormat, TextFormat)
+	c.Order = cmp.Or(c.Order, AutoOrder)
+	c.Summary = cmp.Or(c.Summary, NoSummary)
+	c.ExportFormat = cmp.Or(c.ExportFormat, AnkiFormat)
+	c.ExportDir = cmp.Or(c.ExportDir, defaultExportDir)
 	c.VisualiseType = VisualiseType(cmp.Or(string(c.VisualiseType), string(VisualiseTypeDefault)))
 	c.RequestTimeout = cmp.Or(c.RequestTimeout, defaults.Timeout)
 	c.MaxIdleConns = cmp.Or(c.MaxIdleConns, defaults.MaxIdleConns)
@@ -78,4 +185,8 @@ func (c *Config) ApplyDefaults() {
 	c.RetryMaxAttempts = cmp.Or(c.RetryMaxAttempts, defaults.Retry.MaxAttempts)
 	c.RetryInitialWait = cmp.Or(c.RetryInitialWait, defaults.Retry.InitialWait)
 	c.RetryMaxWait = cmp.Or(c.RetryMaxWait, defaults.Retry.MaxWait)
+	c.RetryAfterMax = cmp.Or(c.RetryAfterMax, defaults.Retry.MaxRetryAfter)
+	c.RateBurst = cmp.Or(c.RateBurst, defaultRateBurst)
+	c.CacheTTL = cmp.Or(c.CacheTTL, cache.DefaultTTL)
+	c.CacheMaxEntries = cmp.Or(c.CacheMaxEntries, cache.DefaultMaxEntries)
 }
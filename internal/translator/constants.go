@@ -1,6 +1,10 @@
 package translator
 
-const defaultWorkers = 4
+const (
+	defaultWorkers           = 4
+	defaultDictionaryPerPage = 100
+	defaultRateBurst         = 1
+)
 
 var (
 	defaultConfigFiles = []string{
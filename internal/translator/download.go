@@ -46,7 +46,7 @@ func downloadFiles(ctx context.Context, urls <-chan string, downloader Downloade
 						return
 					}
 					filename, err := downloader.Download(ctx, job.url)
-					if !sendToChanWithContext(ctx, out, files.File{Error: err, Filename: filename, Index: job.index}) {
+					if !sendToChanWithContext(ctx, out, files.File{Error: err, Filename: filename, URL: job.url, Index: job.index}) {
 						return
 					}
 				}
@@ -0,0 +1,76 @@
+package translator
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/channel"
+)
+
+// deleteWords deletes words from string channel
+func deleteWords(ctx context.Context, translator api.Client, words <-chan string, workers int) <-chan api.OperationResult {
+	out := make(chan api.OperationResult)
+	var wg sync.WaitGroup
+	for range workerCount(workers) {
+		wg.Go(func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case word, ok := <-words:
+					if !ok {
+						return
+					}
+					res := translator.DeleteWord(ctx, word)
+					// Failed requests do not return the word.
+					res.Result.Word = cmp.Or(res.Result.Word, word)
+					sendOperationResult(ctx, out, res)
+				}
+			}
+		})
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// DeleteFromDictionary deletes Words with all their translations from the dictionary.
+func (l *Lingualeo) DeleteFromDictionary(ctx context.Context) {
+	input := channel.ToChannel(ctx, l.Words...)
+	workers := workerCountForItems(l.Workers, len(l.Words))
+	for res := range channel.OrDone(ctx, deleteWords(ctx, l.Client, input, workers)) {
+		if res.Error != nil {
+			slog.Error("cannot delete word from dictionary", "word", res.Result.Word, "error", res.Error)
+			l.report.record(res.Result.Word, OutcomeDeleteFailed, res.Error)
+			continue
+		}
+		l.report.record(res.Result.Word, OutcomeDeleted, nil)
+		if err := PrintDeletedWord(res.Result); err != nil {
+			slog.Error("cannot print deleted word", "word", res.Result.Word, "error", err)
+		}
+	}
+}
+
+// EditInDictionary replaces translations of every word in Words with Translation.
+func (l *Lingualeo) EditInDictionary(ctx context.Context) {
+	for _, word := range l.Words {
+		if ctx.Err() != nil {
+			return
+		}
+		res := l.UpdateTranslation(ctx, word, l.Translation)
+		if res.Error != nil {
+			slog.Error("cannot edit word in dictionary", "word", word, "error", res.Error)
+			l.report.record(word, OutcomeUpdateFailed, res.Error)
+			continue
+		}
+		l.report.record(word, OutcomeUpdated, nil)
+		if err := PrintUpdatedTranslation(res.Result); err != nil {
+			slog.Error("cannot print updated translation", "word", word, "error", err)
+		}
+	}
+}
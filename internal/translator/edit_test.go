@@ -0,0 +1,35 @@
+package translator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trezorg/lingualeo/internal/api"
+	apimock "github.com/trezorg/lingualeo/internal/api/mock"
+)
+
+func TestDeleteFromDictionaryDeletesEveryWord(t *testing.T) {
+	t.Parallel()
+
+	client := apimock.NewMock_Client(t)
+	client.EXPECT().DeleteWord(t.Context(), "hello").Return(api.OperationResult{Result: api.Result{Word: "hello"}}).Once()
+	client.EXPECT().DeleteWord(t.Context(), "world").
+		Return(api.OperationResult{Result: api.Result{Word: "world"}, Error: errors.New("not found")}).Once()
+
+	app := Lingualeo{Client: client, Words: []string{"hello", "world"}, Command: CommandDelete}
+	require.NoError(t, app.Run(t.Context()))
+}
+
+func TestEditInDictionaryReplacesTranslations(t *testing.T) {
+	t.Parallel()
+
+	translations := []string{"привет", "алло"}
+	client := apimock.NewMock_Client(t)
+	client.EXPECT().UpdateTranslation(t.Context(), "hello", translations).
+		Return(api.OperationResult{Result: api.Result{Word: "hello", AddWords: translations}}).Once()
+
+	app := Lingualeo{Client: client, Words: []string{"hello"}, Translation: translations, Command: CommandEdit}
+	require.NoError(t, app.Run(t.Context()))
+}
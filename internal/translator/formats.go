@@ -0,0 +1,336 @@
+package translator
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/trezorg/lingualeo/internal/api"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OutputFormat selects how translation results are printed.
+type OutputFormat string
+
+const (
+	// TextFormat prints colored text for humans.
+	TextFormat OutputFormat = "text"
+	// JSONFormat prints a JSON array of records.
+	JSONFormat OutputFormat = "json"
+	// NDJSONFormat prints a JSON record per line.
+	NDJSONFormat OutputFormat = "ndjson"
+	// YAMLFormat prints a YAML sequence of records.
+	YAMLFormat OutputFormat = "yaml"
+	// CSVFormat prints a CSV row per translation.
+	CSVFormat OutputFormat = "csv"
+	// TableFormat prints aligned columns, see TableOutput.
+	TableFormat OutputFormat = "table"
+)
+
+var (
+	OutputFormats = []OutputFormat{TextFormat, JSONFormat, NDJSONFormat, YAMLFormat, CSVFormat, TableFormat}
+	recordFormats = []OutputFormat{JSONFormat, NDJSONFormat, YAMLFormat, CSVFormat}
+)
+
+var (
+	errOutputFormatAllowed = errors.New("allowed output formats")
+	errNoTranslations      = errors.New("there are no translations")
+)
+
+func (f *OutputFormat) Set(value string) error {
+	format := OutputFormat(value)
+	for _, allowed := range OutputFormats {
+		if format == allowed {
+			*f = format
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %v", errOutputFormatAllowed, OutputFormats)
+}
+
+func (f *OutputFormat) String() string {
+	return string(*f)
+}
+
+// isText reports whether the format is the default colored text.
+func (f OutputFormat) isText() bool {
+	return f == TextFormat || f == ""
+}
+
+// machineReadable reports whether the format is meant for scripts rather than humans.
+func (f OutputFormat) machineReadable() bool {
+	return !f.isText() && f != TableFormat
+}
+
+// ErrorOutputer is implemented by outputers reporting failed translations themselves
+// instead of the colored messages.
+type ErrorOutputer interface {
+	OutputError(ctx context.Context, word string, err error) error
+}
+
+// PreparingOutputer is implemented by outputers doing slow work for a result,
+// e.g. downloads, which can run concurrently before the result is written.
+type PreparingOutputer interface {
+	// Prepare does the work and returns the function writing the result.
+	Prepare(ctx context.Context, result api.Result) func() error
+}
+
+// Record is the serialized translation result.
+type Record struct {
+	Word            string              `json:"word" yaml:"word"`
+	Transcription   string              `json:"transcription,omitempty" yaml:"transcription,omitempty"`
+	Pos             string              `json:"pos,omitempty" yaml:"pos,omitempty"`
+	Picture         string              `json:"picture,omitempty" yaml:"picture,omitempty"`
+	SoundURL        string              `json:"sound_url,omitempty" yaml:"sound_url,omitempty"`
+	TranslateSource string              `json:"translate_source,omitempty" yaml:"translate_source,omitempty"`
+	Error           string              `json:"error,omitempty" yaml:"error,omitempty"`
+	WordForms       []RecordWordForm    `json:"word_forms,omitempty" yaml:"word_forms,omitempty"`
+	Translations    []RecordTranslation `json:"translations" yaml:"translations"`
+	WordID          int                 `json:"word_id" yaml:"word_id"`
+	WordTop         int                 `json:"word_top" yaml:"word_top"`
+	InDictionary    bool                `json:"in_dictionary" yaml:"in_dictionary"`
+}
+
+// RecordWordForm is a serialized inflected form of a word.
+type RecordWordForm struct {
+	Word string `json:"word" yaml:"word"`
+	Type string `json:"type" yaml:"type"`
+}
+
+// RecordTranslation is a serialized translation of a word.
+type RecordTranslation struct {
+	Value        string `json:"value" yaml:"value"`
+	Context      string `json:"context,omitempty" yaml:"context,omitempty"`
+	Picture      string `json:"picture,omitempty" yaml:"picture,omitempty"`
+	Votes        int    `json:"votes" yaml:"votes"`
+	InDictionary bool   `json:"in_dictionary" yaml:"in_dictionary"`
+}
+
+// NewRecord converts a translation result.
+func NewRecord(result api.Result) Record {
+	record := Record{
+		Word:            result.Word,
+		Transcription:   result.Transcription,
+		Pos:             result.Pos,
+		Picture:         result.Picture,
+		SoundURL:        result.SoundURL,
+		InDictionary:    result.InDictionary(),
+		Translations:    make([]RecordTranslation, 0, len(result.Translate)),
+		TranslateSource: result.TranslateSource,
+		WordID:          result.WordID,
+		WordTop:         result.WordTop,
+	}
+	for _, form := range result.WordForms {
+		record.WordForms = append(record.WordForms, RecordWordForm{Word: form.Word, Type: form.Type})
+	}
+	for _, word := range result.Translate {
+		record.Translations = append(record.Translations, RecordTranslation{
+			Value:        word.Value,
+			Context:      word.Context,
+			Picture:      word.Picture,
+			Votes:        word.Votes,
+			InDictionary: bool(word.Exists),
+		})
+	}
+	return record
+}
+
+// errorRecord converts a failed translation.
+func errorRecord(word string, err error) Record {
+	return Record{Word: word, Error: err.Error(), Translations: []RecordTranslation{}}
+}
+
+// recordEncoder writes records one by one.
+type recordEncoder interface {
+	encode(record Record) error
+	close() error
+}
+
+// RecordOutput serializes results with a machine-readable format.
+// Close must be called to complete the document.
+type RecordOutput struct {
+	encoder recordEncoder
+	mu      sync.Mutex
+}
+
+// NewRecordOutput creates an outputer writing the format into w.
+func NewRecordOutput(format OutputFormat, w io.Writer) (*RecordOutput, error) {
+	var encoder recordEncoder
+	switch format {
+	case JSONFormat:
+		encoder = &jsonEncoder{w: w}
+	case NDJSONFormat:
+		encoder = ndjsonEncoder{w: w}
+	case YAMLFormat:
+		encoder = &yamlEncoder{w: w}
+	case CSVFormat:
+		encoder = &csvEncoder{w: csv.NewWriter(w)}
+	default:
+		return nil, fmt.Errorf("%w: %v", errOutputFormatAllowed, recordFormats)
+	}
+	return &RecordOutput{encoder: encoder}, nil
+}
+
+func (o *RecordOutput) Output(ctx context.Context, result api.Result) error {
+	return o.write(ctx, NewRecord(result))
+}
+
+func (o *RecordOutput) OutputError(ctx context.Context, word string, err error) error {
+	return o.write(ctx, errorRecord(word, err))
+}
+
+func (o *RecordOutput) write(ctx context.Context, record Record) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.encoder.encode(record)
+}
+
+// Close completes the document, e.g. closes the JSON array.
+func (o *RecordOutput) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.encoder.close()
+}
+
+type jsonEncoder struct {
+	w       io.Writer
+	started bool
+}
+
+func (e *jsonEncoder) encode(record Record) error {
+	separator := ",\n"
+	if !e.started {
+		separator = "[\n"
+		e.started = true
+	}
+	data, err := json.Marshal(record, jsontext.WithIndentPrefix("  "), jsontext.WithIndent("  "))
+	if err != nil {
+		return fmt.Errorf("encode record: %w", err)
+	}
+	_, err = fmt.Fprintf(e.w, "%s  %s", separator, data)
+	return err
+}
+
+func (e *jsonEncoder) close() error {
+	if !e.started {
+		_, err := io.WriteString(e.w, "[]\n")
+		return err
+	}
+	_, err := io.WriteString(e.w, "\n]\n")
+	return err
+}
+
+type ndjsonEncoder struct {
+	w io.Writer
+}
+
+func (e ndjsonEncoder) encode(record Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encode record: %w", err)
+	}
+	_, err = fmt.Fprintf(e.w, "%s\n", data)
+	return err
+}
+
+func (ndjsonEncoder) close() error {
+	return nil
+}
+
+// yamlEncoder writes every record as an item of a single sequence.
+type yamlEncoder struct {
+	w       io.Writer
+	started bool
+}
+
+func (e *yamlEncoder) encode(record Record) error {
+	data, err := yaml.Marshal([]Record{record})
+	if err != nil {
+		return fmt.Errorf("encode record: %w", err)
+	}
+	e.started = true
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *yamlEncoder) close() error {
+	if e.started {
+		return nil
+	}
+	_, err := io.WriteString(e.w, "[]\n")
+	return err
+}
+
+var csvHeader = []string{
+	"word", "transcription", "translation", "votes", "context", "picture", "sound_url",
+	"word_forms", "word_id", "translate_source", "word_top", "in_dictionary", "error",
+}
+
+// csvEncoder writes a row per translation. Words without translations get a single row.
+type csvEncoder struct {
+	w       *csv.Writer
+	started bool
+}
+
+func (e *csvEncoder) encode(record Record) error {
+	if !e.started {
+		e.started = true
+		if err := e.w.Write(csvHeader); err != nil {
+			return err
+		}
+	}
+	forms := make([]api.WordForm, 0, len(record.WordForms))
+	for _, form := range record.WordForms {
+		forms = append(forms, api.WordForm{Word: form.Word, Type: form.Type})
+	}
+	translations := record.Translations
+	if len(translations) == 0 {
+		translations = []RecordTranslation{{}}
+	}
+	for _, translation := range translations {
+		votes := ""
+		if translation.Value != "" {
+			votes = strconv.Itoa(translation.Votes)
+		}
+		row := []string{
+			record.Word,
+			record.Transcription,
+			translation.Value,
+			votes,
+			translation.Context,
+			translation.Picture,
+			record.SoundURL,
+			formatWordForms(forms),
+			strconv.Itoa(record.WordID),
+			record.TranslateSource,
+			strconv.Itoa(record.WordTop),
+			strconv.FormatBool(record.InDictionary),
+			record.Error,
+		}
+		if err := e.w.Write(row); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) close() error {
+	if !e.started {
+		if err := e.w.Write(csvHeader); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
@@ -0,0 +1,165 @@
+package translator
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json/v2"
+	"errors"
+	"strings"
+	"testing"
+
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/api/mock"
+	"github.com/trezorg/lingualeo/internal/fakeapi"
+)
+
+var formatResult = api.Result{
+	Word:          "hello",
+	Transcription: "həˈləʊ",
+	SoundURL:      "https://example.com/hello.mp3",
+	WordForms:     []api.WordForm{{Word: "hellos", Type: "plural"}},
+	WordID:        42,
+	WordTop:       3,
+	Translate: []api.Word{
+		{Value: "привет", Votes: 10, Context: "greeting", Picture: "https://example.com/hello.png", Exists: true},
+		{Value: "алло", Votes: 2},
+	},
+}
+
+func writeRecords(t *testing.T, format OutputFormat) string {
+	t.Helper()
+	out := &bytes.Buffer{}
+	output, err := NewRecordOutput(format, out)
+	require.NoError(t, err)
+	require.NoError(t, output.Output(t.Context(), formatResult))
+	require.NoError(t, output.OutputError(t.Context(), "qwzx", errNoTranslations))
+	require.NoError(t, output.Close())
+	return out.String()
+}
+
+func TestRecordOutputJSON(t *testing.T) {
+	var records []Record
+	require.NoError(t, json.Unmarshal([]byte(writeRecords(t, JSONFormat)), &records))
+	require.Equal(t, []Record{NewRecord(formatResult), errorRecord("qwzx", errNoTranslations)}, records)
+	require.True(t, records[0].InDictionary)
+	require.Equal(t, "greeting", records[0].Translations[0].Context)
+}
+
+func TestRecordOutputNDJSON(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(writeRecords(t, NDJSONFormat)), "\n")
+	require.Len(t, lines, 2)
+	record := Record{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+	require.Equal(t, errNoTranslations.Error(), record.Error)
+}
+
+func TestRecordOutputYAML(t *testing.T) {
+	var records []Record
+	require.NoError(t, yaml.Unmarshal([]byte(writeRecords(t, YAMLFormat)), &records))
+	require.Len(t, records, 2)
+	require.Equal(t, NewRecord(formatResult), records[0])
+}
+
+func TestRecordOutputCSV(t *testing.T) {
+	rows, err := csv.NewReader(strings.NewReader(writeRecords(t, CSVFormat))).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		csvHeader,
+		{"hello", "həˈləʊ", "привет", "10", "greeting", "https://example.com/hello.png", "https://example.com/hello.mp3", "hellos (plural)", "42", "", "3", "true", ""},
+		{"hello", "həˈləʊ", "алло", "2", "", "", "https://example.com/hello.mp3", "hellos (plural)", "42", "", "3", "true", ""},
+		{"qwzx", "", "", "", "", "", "", "", "0", "", "0", "false", errNoTranslations.Error()},
+	}, rows)
+}
+
+func TestRecordOutputWordDetails(t *testing.T) {
+	result := translateWordResult(fakeapi.SearchWord)
+	require.NoError(t, result.Error)
+	expected := []RecordWordForm{{Word: "accommodation", Type: "прил."}}
+
+	for _, format := range []OutputFormat{JSONFormat, YAMLFormat} {
+		out := &bytes.Buffer{}
+		output, err := NewRecordOutput(format, out)
+		require.NoError(t, err)
+		require.NoError(t, output.Output(t.Context(), result.Result))
+		require.NoError(t, output.Close())
+		require.Contains(t, out.String(), "word_top", format)
+
+		var records []Record
+		if format == JSONFormat {
+			require.NoError(t, json.Unmarshal(out.Bytes(), &records))
+		} else {
+			require.NoError(t, yaml.Unmarshal(out.Bytes(), &records))
+		}
+		require.Len(t, records, 1, format)
+		require.Equal(t, expected, records[0].WordForms, format)
+		require.Equal(t, 102085, records[0].WordID, format)
+		require.Equal(t, "base", records[0].TranslateSource, format)
+		require.Zero(t, records[0].WordTop, format)
+	}
+
+	out := &bytes.Buffer{}
+	output, err := NewRecordOutput(CSVFormat, out)
+	require.NoError(t, err)
+	require.NoError(t, output.Output(t.Context(), result.Result))
+	require.NoError(t, output.Close())
+	rows, err := csv.NewReader(out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 1+len(fakeapi.Expected))
+	require.Equal(t, []string{"accommodation (прил.)", "102085", "base", "0"}, rows[1][7:11])
+}
+
+func TestRecordOutputWithoutResults(t *testing.T) {
+	for _, format := range []OutputFormat{JSONFormat, YAMLFormat} {
+		out := &bytes.Buffer{}
+		output, err := NewRecordOutput(format, out)
+		require.NoError(t, err)
+		require.NoError(t, output.Close())
+		require.Equal(t, "[]\n", out.String(), format)
+	}
+}
+
+func TestOutputFormatSet(t *testing.T) {
+	var format OutputFormat
+	require.NoError(t, format.Set("ndjson"))
+	require.Equal(t, NDJSONFormat, format)
+	require.ErrorIs(t, format.Set("xml"), errOutputFormatAllowed)
+
+	_, err := NewRecordOutput(TextFormat, &bytes.Buffer{})
+	require.ErrorIs(t, err, errOutputFormatAllowed)
+}
+
+func TestRunOutputsErrorsAsRecords(t *testing.T) {
+	client := mock.NewMock_Client(t)
+	client.EXPECT().TranslateWord(testifymock.Anything, "hello").Return(api.OperationResult{Result: formatResult}).Once()
+	client.EXPECT().TranslateWord(testifymock.Anything, "qwzx").Return(api.OperationResult{Result: api.Result{Word: "qwzx"}}).Once()
+	client.EXPECT().TranslateWord(testifymock.Anything, "fail").Return(api.OperationResult{
+		Result: api.Result{Word: "fail"},
+		Error:  errors.New("server error"),
+	}).Once()
+
+	out := &bytes.Buffer{}
+	output, err := NewRecordOutput(NDJSONFormat, out)
+	require.NoError(t, err)
+	app := Lingualeo{
+		Client:   client,
+		Outputer: output,
+		Config:   Config{OutputFormat: NDJSONFormat, Workers: 1},
+		Words:    []string{"hello", "qwzx", "fail"},
+	}
+
+	require.NoError(t, app.Run(t.Context()))
+	records := map[string]Record{}
+	for line := range strings.SplitSeq(strings.TrimSpace(out.String()), "\n") {
+		record := Record{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records[record.Word] = record
+	}
+	require.Len(t, records, 3)
+	require.Len(t, records["hello"].Translations, 2)
+	require.Equal(t, errNoTranslations.Error(), records["qwzx"].Error)
+	require.Equal(t, "server error", records["fail"].Error)
+}
@@ -5,8 +5,11 @@ import (
 	"log/slog"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/trezorg/lingualeo/internal/api"
 	"github.com/trezorg/lingualeo/internal/api/mock"
+	"github.com/trezorg/lingualeo/internal/channel"
 	"github.com/trezorg/lingualeo/internal/fakeapi"
 )
 
@@ -50,9 +53,10 @@ func TestProcessTranslationResponseJson(t *testing.T) {
 		Outputer:   outputer,
 	}
 
-	ch := args.translateToChan(t.Context(), searchWords)
+	ch := args.translateToChan(t.Context(), channel.ToChannel(t.Context(), wordEntries(searchWords)...), len(searchWords))
 
 	for result := range ch {
-		fakeapi.CheckResult(t, result, searchWords[0], fakeapi.Expected)
+		require.NoError(t, result.Error)
+		fakeapi.CheckResult(t, result.Result, searchWords[0], fakeapi.Expected)
 	}
 }
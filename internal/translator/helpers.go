@@ -1,23 +1,23 @@
 package translator
 
 import (
-	"unicode"
+	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/lang"
 )
 
-func isRussianWord(s string) bool {
-	for _, symbol := range s {
-		if !unicode.Is(unicode.Cyrillic, symbol) && !unicode.Is(unicode.Number, symbol) {
-			return false
-		}
+// reverseWords returns translations to translate back: the source language
+// translations of a word given in the target language. When both languages
+// share a script, words are told apart by the transcription Lingualeo gives
+// only for the source language.
+func reverseWords(pair lang.Pair, result api.Result) []string {
+	if !pair.DistinctScripts() && !result.InTargetLanguage() {
+		return nil
 	}
-	return true
-}
-
-func isEnglishWord(s string) bool {
-	for _, symbol := range s {
-		if !unicode.Is(unicode.Latin, symbol) && !unicode.Is(unicode.Number, symbol) {
-			return false
+	words := make([]string, 0, len(result.Translate))
+	for _, word := range result.Translate {
+		if pair.Source.IsWord(word.Value) {
+			words = append(words, word.Value)
 		}
 	}
-	return true
+	return words
 }
@@ -0,0 +1,101 @@
+package translator
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// stdinInput is the input file name reading words from stdin.
+const stdinInput = "-"
+
+// Entry is a word or phrase to translate with optional custom translations.
+type Entry struct {
+	Word         string
+	Translations []string
+}
+
+func wordEntries(words []string) []Entry {
+	entries := make([]Entry, 0, len(words))
+	for _, word := range words {
+		entries = append(entries, Entry{Word: word})
+	}
+	return entries
+}
+
+// parseEntry parses an input line: a word or phrase optionally followed by
+// tab separated translations. Blank lines and lines starting with # are skipped.
+func parseEntry(line string) (Entry, bool) {
+	if trimmed := strings.TrimSpace(line); trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return Entry{}, false
+	}
+	columns := strings.Split(line, "\t")
+	entry := Entry{Word: strings.TrimSpace(columns[0])}
+	if entry.Word == "" {
+		return Entry{}, false
+	}
+	for _, column := range columns[1:] {
+		if translation := strings.TrimSpace(column); translation != "" {
+			entry.Translations = append(entry.Translations, translation)
+		}
+	}
+	return entry, true
+}
+
+// readEntries sends entries read line by line from r until EOF or cancellation.
+func readEntries(ctx context.Context, r io.Reader, out chan<- Entry) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		entry, ok := parseEntry(scanner.Text())
+		if !ok {
+			continue
+		}
+		if !sendToChanWithContext(ctx, out, entry) {
+			return context.Cause(ctx)
+		}
+	}
+	return scanner.Err()
+}
+
+func (l *Lingualeo) openInput() (io.ReadCloser, error) {
+	if l.InputFile == stdinInput {
+		stdin := l.stdin
+		if stdin == nil {
+			stdin = os.Stdin
+		}
+		return io.NopCloser(stdin), nil
+	}
+	return os.Open(l.InputFile)
+}
+
+// entries streams the positional words followed by the words read from InputFile.
+// The error channel gets the input error, if any, and is closed when reading is over.
+func (l *Lingualeo) entries(ctx context.Context) (<-chan Entry, <-chan error) {
+	out := make(chan Entry)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		defer close(out)
+		for _, entry := range wordEntries(l.Words) {
+			if !sendToChanWithContext(ctx, out, entry) {
+				return
+			}
+		}
+		if l.InputFile == "" {
+			return
+		}
+		input, err := l.openInput()
+		if err != nil {
+			errc <- fmt.Errorf("open input: %w", err)
+			return
+		}
+		defer func() { _ = input.Close() }()
+		if err = readEntries(ctx, input, out); err != nil {
+			errc <- fmt.Errorf("read input %s: %w", l.InputFile, err)
+		}
+	}()
+	return out, errc
+}
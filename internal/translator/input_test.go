@@ -0,0 +1,92 @@
+package translator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/api/mock"
+)
+
+func TestParseEntry(t *testing.T) {
+	tests := []struct {
+		line  string
+		entry Entry
+		ok    bool
+	}{
+		{line: "hello", entry: Entry{Word: "hello"}, ok: true},
+		{line: "  look after  ", entry: Entry{Word: "look after"}, ok: true},
+		{line: "hello\tпривет", entry: Entry{Word: "hello", Translations: []string{"привет"}}, ok: true},
+		{line: "hello\tпривет\t\tздравствуй ", entry: Entry{Word: "hello", Translations: []string{"привет", "здравствуй"}}, ok: true},
+		{line: "", ok: false},
+		{line: "   ", ok: false},
+		{line: "# vocabulary", ok: false},
+		{line: "\tпривет", ok: false},
+	}
+
+	for _, tt := range tests {
+		entry, ok := parseEntry(tt.line)
+		require.Equal(t, tt.ok, ok, tt.line)
+		require.Equal(t, tt.entry, entry, tt.line)
+	}
+}
+
+func TestReadEntriesStopsOnCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	out := make(chan Entry)
+	cancel()
+
+	err := readEntries(ctx, strings.NewReader("hello\nworld\n"), out)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestTranslateWithReverseStreamsInput(t *testing.T) {
+	t.Parallel()
+
+	client := mock.NewMock_Client(t)
+	for _, word := range []string{"hello", "world", "house"} {
+		client.EXPECT().TranslateWord(testifymock.Anything, word).Return(api.OperationResult{
+			Result: api.Result{Word: word, Translate: []api.Word{{Value: "top " + word}}},
+		}).Once()
+	}
+	client.EXPECT().AddWord(testifymock.Anything, "house", "top house").Return(api.OperationResult{}).Once()
+	client.EXPECT().AddWord(testifymock.Anything, "hello", "привет").Return(api.OperationResult{}).Once()
+	client.EXPECT().AddWord(testifymock.Anything, "world", "мир").Return(api.OperationResult{}).Once()
+	client.EXPECT().AddWord(testifymock.Anything, "world", "свет").Return(api.OperationResult{}).Once()
+
+	output := &outputCollector{}
+	app := Lingualeo{
+		Client:   client,
+		Outputer: output,
+		Config:   Config{Add: true, Workers: 2},
+		Words:    []string{"house"},
+		stdin:    strings.NewReader("# words\nhello\tпривет\n\nworld\tмир\tсвет\n"),
+	}
+	app.InputFile = stdinInput
+
+	require.NoError(t, app.TranslateWithReverse(t.Context()))
+	slices.Sort(output.words)
+	require.Equal(t, []string{"hello", "house", "world"}, output.words)
+}
+
+func TestTranslateWithReverseReportsMissingInput(t *testing.T) {
+	t.Parallel()
+
+	app := Lingualeo{
+		Client:    mock.NewMock_Client(t),
+		Outputer:  &outputCollector{},
+		InputFile: filepath.Join(t.TempDir(), "missing.txt"),
+	}
+
+	err := app.TranslateWithReverse(t.Context())
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -1,10 +1,13 @@
 package translator
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"net/http"
 	"strings"
 	"sync"
 
@@ -20,7 +23,11 @@ import (
 	"golang.org/x/text/language"
 )
 
-var errUnknownVisualiseType = errors.New("unknown visualize type")
+var (
+	errUnknownVisualiseType = errors.New("unknown visualize type")
+	errTranslationExists    = errors.New("translation is already in the dictionary")
+	errExistingWord         = errors.New("word is already in the dictionary")
+)
 
 type Lingualeo struct {
 	// Embedded interfaces (dependencies)
@@ -28,18 +35,29 @@ type Lingualeo struct {
 	Downloader `json:"-" yaml:"-" toml:"-"`
 	Pronouncer `json:"-" yaml:"-" toml:"-"`
 	Outputer   `json:"-" yaml:"-" toml:"-"`
+	Selector   `json:"-" yaml:"-" toml:"-"`
 
 	// Embedded config - inline tags preserve flat access for config file parsing
 	//nolint:revive // inline tags required for yaml/toml/json v2 embedding
 	Config `yaml:",inline" json:",inline" toml:",inline"`
 
 	// Runtime inputs (not serialized)
-	ConfigPath  string   // Path to config file (renamed from Config to avoid collision)
-	Words       []string // Words to translate
-	Translation []string // Custom translation override
+	ConfigPath      string              // Path to config file (renamed from Config to avoid collision)
+	Words           []string            // Words to translate
+	InputFile       string              // File with words to translate, "-" means stdin
+	Translation     []string            // Custom translation override
+	Command         Command             // Command to run
+	DictionaryQuery api.DictionaryQuery // Dictionary page to list
+	AllPages        bool                // List all dictionary pages starting from DictionaryQuery
+
+	session     *session
+	report      *Report
+	httpClient  *http.Client
+	stdin       io.Reader
+	middlewares []api.Middleware
 }
 
-func visualizer(vt VisualiseType) (Visualizer, error) {
+func visualizer(vt VisualiseType, client *http.Client) (Visualizer, error) {
 	switch vt {
 	case Default:
 		return browser.New(), nil
@@ -47,17 +65,17 @@ func visualizer(vt VisualiseType) (Visualizer, error) {
 		if term.Mode() == term.Unknown {
 			return browser.New(), nil
 		}
-		return term.New(), nil
+		return term.New(term.WithHTTPClient(client)), nil
 	default:
 		return nil, fmt.Errorf("%w: %s", errUnknownVisualiseType, vt)
 	}
 }
 
-func outputer(visualize bool, vt VisualiseType) (Outputer, error) {
+func outputer(visualize bool, vt VisualiseType, client *http.Client) (Outputer, error) {
 	if !visualize {
 		return Output{}, nil
 	}
-	viz, err := visualizer(vt)
+	viz, err := visualizer(vt, client)
 	if err != nil {
 		return nil, err
 	}
@@ -67,7 +85,7 @@ func outputer(visualize bool, vt VisualiseType) (Outputer, error) {
 // NewOutputer creates an outputer based on visualize setting.
 // Exported for use in main.go for explicit dependency injection.
 func NewOutputer(visualize bool, vt VisualiseType) (Outputer, error) {
-	return outputer(visualize, vt)
+	return outputer(visualize, vt, nil)
 }
 
 // sendOperationResult sends a result to the output channel.
@@ -156,21 +174,43 @@ func (l *Lingualeo) Validate() error {
 	return err
 }
 
-// translateWords translate words from string channel
-func translateWords(ctx context.Context, translator api.Client, results <-chan string, workers int) <-chan api.OperationResult {
-	out := make(chan api.OperationResult)
+// indexedResult is an operation result with the position of its input, see inputOrder.
+type indexedResult = channel.Indexed[api.OperationResult]
+
+// translateWords translate words from entry channel.
+// Custom translations of an entry are kept in the result AddWords.
+// Results are indexed by the entry position.
+func translateWords(ctx context.Context, translator api.Client, entries <-chan Entry, workers int) <-chan indexedResult {
+	out := make(chan indexedResult)
+	jobs := make(chan channel.Indexed[Entry])
 	var wg sync.WaitGroup
+	wg.Go(func() {
+		defer close(jobs)
+		idx := 0
+		for entry := range channel.OrDone(ctx, entries) {
+			if !sendToChanWithContext(ctx, jobs, channel.Indexed[Entry]{Value: entry, Index: idx}) {
+				return
+			}
+			idx++
+		}
+	})
 	for range workerCount(workers) {
 		wg.Go(func() {
 			for {
 				select {
 				case <-ctx.Done():
 					return
-				case word, ok := <-results:
+				case job, ok := <-jobs:
 					if !ok {
 						return
 					}
-					sendOperationResult(ctx, out, translator.TranslateWord(ctx, word))
+					res := translator.TranslateWord(ctx, job.Value.Word)
+					// Failed requests do not return the word.
+					res.Result.Word = cmp.Or(res.Result.Word, job.Value.Word)
+					if len(job.Value.Translations) > 0 {
+						res.Result.SetTranslation(job.Value.Translations)
+					}
+					_ = sendToChanWithContext(ctx, out, indexedResult{Value: res, Index: job.Index})
 				}
 			}
 		})
@@ -182,24 +222,58 @@ func translateWords(ctx context.Context, translator api.Client, results <-chan s
 	return out
 }
 
-// addWords add words
-func addWords(ctx context.Context, translator api.Client, results <-chan api.Result, workers int) <-chan api.OperationResult {
-	out := make(chan api.OperationResult)
+// wordAdder adds a translation of a word into the dictionary.
+type wordAdder interface {
+	AddWord(ctx context.Context, word string, translate string) api.OperationResult
+}
+
+// dryRunAdder pretends to add translations without calling the API.
+type dryRunAdder struct{}
+
+func (dryRunAdder) AddWord(_ context.Context, word string, _ string) api.OperationResult {
+	return api.OperationResult{Result: api.Result{Word: word}}
+}
+
+// addWords add words. Translations already in the dictionary are not sent again,
+// they are reported with errTranslationExists.
+// Results are indexed by the position of the translation in the input.
+func addWords(ctx context.Context, translator wordAdder, results <-chan api.Result, workers int) <-chan indexedResult {
+	out := make(chan indexedResult)
+	jobs := make(chan channel.Indexed[api.Result])
 	var wg sync.WaitGroup
+	wg.Go(func() {
+		defer close(jobs)
+		idx := 0
+		for res := range channel.OrDone(ctx, results) {
+			if !sendToChanWithContext(ctx, jobs, channel.Indexed[api.Result]{Value: res, Index: idx}) {
+				return
+			}
+			idx += len(res.AddWords)
+		}
+	})
 	for range workerCount(workers) {
 		wg.Go(func() {
 			for {
 				select {
 				case <-ctx.Done():
 					return
-				case res, ok := <-results:
+				case job, ok := <-jobs:
 					if !ok {
 						return
 					}
-					for _, translate := range res.AddWords {
+					res := job.Value
+					for i, translate := range res.AddWords {
+						index := job.Index + i
+						if res.HasExistingTranslation(translate) {
+							skipped := api.OperationResult{Result: res, Error: errTranslationExists}
+							skipped.Result.AddWords = []string{translate}
+							_ = sendToChanWithContext(ctx, out, indexedResult{Value: skipped, Index: index})
+							continue
+						}
 						added := translator.AddWord(ctx, res.Word, translate)
+						added.Result.Word = cmp.Or(added.Result.Word, res.Word)
 						added.Result.AddWords = []string{translate}
-						sendOperationResult(ctx, out, added)
+						_ = sendToChanWithContext(ctx, out, indexedResult{Value: added, Index: index})
 					}
 				}
 			}
@@ -227,32 +301,32 @@ func (l *Lingualeo) checkMediaPlayer() error {
 	return nil
 }
 
-func (l *Lingualeo) translateWords(ctx context.Context, words []string) <-chan api.OperationResult {
-	results := make(chan api.OperationResult, len(words))
-	input := channel.ToChannel(ctx, words...)
-	workers := workerCountForItems(l.Workers, len(words))
+// translateEntries translates streamed entries. Size is the number of entries
+// if known, zero otherwise. The results keep the input order when ordered, see Config.Order.
+// Failed words, skipped words and words without translations are sent with an error,
+// see outputFailure.
+func (l *Lingualeo) translateEntries(ctx context.Context, input <-chan Entry, size int) <-chan api.OperationResult {
+	results := make(chan api.OperationResult, size)
+	workers := workerCountForItems(l.Workers, size)
 	go func() {
 		defer close(results)
-		ch := translateWords(ctx, l.Client, input, workers)
-		for res := range channel.OrDone(ctx, ch) {
-			if res.Error != nil {
-				err := messages.Message(
-					messages.RED,
-					"%s\n",
-					cases.Title(language.Make(strings.ToLower(res.Error.Error()))),
-				)
-				if err != nil {
-					slog.Error("cannot show message", "error", err)
-				}
-				continue
-			}
-			if len(res.Result.Translate) == 0 {
-				_ = messages.Message(messages.RED, "There are no translations for word: ")
-				err := messages.Message(messages.GREEN, "['%s']\n", res.Result.Word)
-				if err != nil {
-					slog.Error("cannot show message", "error", err)
+		ch := inputOrder(ctx, translateWords(ctx, l.Client, input, workers), l.ordered(size))
+		for indexed := range channel.OrDone(ctx, ch) {
+			res := indexed.Value
+			switch {
+			case res.Error != nil:
+				l.report.record(res.Result.Word, OutcomeTranslateFailed, res.Error)
+			case l.SkipExisting && res.Result.InDictionary():
+				l.report.record(res.Result.Word, OutcomeSkipped, nil)
+				res.Error = errExistingWord
+			default:
+				res.Result.FilterTranslations(l.Top, l.MinVotes)
+				if len(res.Result.Translate) == 0 {
+					l.report.record(res.Result.Word, OutcomeNoTranslation, errNoTranslations)
+					res.Error = errNoTranslations
+				} else {
+					l.report.record(res.Result.Word, OutcomeTranslated, nil)
 				}
-				continue
 			}
 			if !sendToChanWithContext(ctx, results, res) {
 				return
@@ -262,12 +336,28 @@ func (l *Lingualeo) translateWords(ctx context.Context, words []string) <-chan a
 	return results
 }
 
-func (l *Lingualeo) prepareResultToAdd(result *api.Result) bool {
+func (l *Lingualeo) prepareResultToAdd(ctx context.Context, result *api.Result) bool {
 	// Custom translation
 	if len(l.Translation) > 0 {
 		result.SetTranslation(l.Translation)
 		return true
 	}
+	// Custom translation given in the input
+	if len(result.AddWords) > 0 {
+		return true
+	}
+	// Translations chosen by the user
+	if l.Selector != nil {
+		translations, err := l.Select(ctx, *result)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				slog.Error("cannot select translations", "word", result.Word, "error", err)
+			}
+			return false
+		}
+		result.SetTranslation(translations)
+		return len(result.AddWords) > 0
+	}
 	// Use top translation from API if available
 	if len(result.Translate) > 0 {
 		translations := make([]string, 0, len(result.Translate))
@@ -286,6 +376,7 @@ func (l *Lingualeo) downloadAndPronounce(ctx context.Context, urls <-chan string
 	for res := range channel.OrDone(ctx, fileChannel) {
 		if res.Error != nil {
 			slog.Error("cannot download", "error", res.Error)
+			l.report.recordSound(res.URL, OutcomeDownloadFailed, res.Error)
 			continue
 		}
 		if res.Filename == "" {
@@ -293,6 +384,9 @@ func (l *Lingualeo) downloadAndPronounce(ctx context.Context, urls <-chan string
 		}
 		if err := l.Play(ctx, res.Filename); err != nil {
 			slog.Error("cannot play filename", "filename", res.Filename, "error", err)
+			l.report.recordSound(res.URL, OutcomePlayFailed, err)
+		} else {
+			l.report.recordSound(res.URL, OutcomePlayed, nil)
 		}
 		if err := l.Remove(res.Filename); err != nil {
 			slog.Error("cannot remove filename", "filename", res.Filename, "error", err)
@@ -304,7 +398,10 @@ func (l *Lingualeo) playURLs(ctx context.Context, urls <-chan string) {
 	for url := range channel.OrDone(ctx, urls) {
 		if err := l.Play(ctx, url); err != nil {
 			slog.Error("cannot play url", "url", url, "error", err)
+			l.report.recordSound(url, OutcomePlayFailed, err)
+			continue
 		}
+		l.report.recordSound(url, OutcomePlayed, nil)
 	}
 }
 
@@ -317,15 +414,33 @@ func (l *Lingualeo) Pronounce(ctx context.Context, urls <-chan string, wordCount
 	l.playURLs(ctx, urls)
 }
 
+// AddToDictionary adds the translations of the results. With DryRun it only
+// prints the translations that would be added.
 func (l *Lingualeo) AddToDictionary(ctx context.Context, resultsToAdd <-chan api.Result, wordCount int) {
 	workers := workerCountForItems(l.Workers, wordCount)
-	ch := addWords(ctx, l.Client, resultsToAdd, workers)
-	for res := range ch {
-		if res.Error != nil {
+	var adder wordAdder = l.Client
+	if l.DryRun {
+		adder = dryRunAdder{}
+	}
+	ch := inputOrder(ctx, addWords(ctx, adder, resultsToAdd, workers), l.ordered(wordCount))
+	for indexed := range ch {
+		res := indexed.Value
+		var err error
+		switch {
+		case errors.Is(res.Error, errTranslationExists):
+			l.report.record(res.Result.Word, OutcomeSkipped, nil)
+			err = PrintSkippedTranslation(res.Result)
+		case res.Error != nil:
 			slog.Error("cannot add word to dictionary", "word", res.Result.Word, "error", res.Error)
+			l.report.record(res.Result.Word, OutcomeAddFailed, res.Error)
 			continue
+		case l.DryRun:
+			err = PrintDryRunTranslation(res.Result)
+		default:
+			l.report.record(res.Result.Word, OutcomeAdded, nil)
+			err = PrintAddedTranslation(res.Result)
 		}
-		if err := PrintAddedTranslation(res.Result); err != nil {
+		if err != nil {
 			slog.Error("cannot print added translation", "word", res.Result.Word, "error", err)
 		}
 	}
@@ -334,13 +449,17 @@ func (l *Lingualeo) AddToDictionary(ctx context.Context, resultsToAdd <-chan api
 type Channels struct {
 	sound   <-chan string
 	add     <-chan api.Result
-	results <-chan api.Result
+	results <-chan api.OperationResult
 }
 
 func (l *Lingualeo) Process(ctx context.Context, words []string, wg *sync.WaitGroup) Channels {
-	soundChan := make(chan string, len(words))
-	addWordChan := make(chan api.Result, len(words))
-	resultsChan := make(chan api.Result, len(words))
+	return l.process(ctx, channel.ToChannel(ctx, wordEntries(words)...), len(words), wg)
+}
+
+func (l *Lingualeo) process(ctx context.Context, input <-chan Entry, size int, wg *sync.WaitGroup) Channels {
+	soundChan := make(chan string, size)
+	addWordChan := make(chan api.Result, size)
+	resultsChan := make(chan api.OperationResult, size)
 
 	go func() {
 		defer func() {
@@ -350,25 +469,29 @@ func (l *Lingualeo) Process(ctx context.Context, words []string, wg *sync.WaitGr
 			close(resultsChan)
 		}()
 
-		for result := range l.translateWords(ctx, words) {
+		for result := range l.translateEntries(ctx, input, size) {
+			// Failures are output in order with the other results.
 			if result.Error != nil {
-				slog.Error("cannot translate word", "word", result.Result.Word, "error", result.Error)
+				if !sendToChanWithContext(ctx, resultsChan, result) {
+					return
+				}
 				continue
 			}
 			if l.Sound {
+				l.report.addSound(result.Result.SoundURL, result.Result.Word)
 				if !sendToChanWithContext(ctx, soundChan, result.Result.SoundURL) {
 					return
 				}
 			}
 
 			if l.Add {
-				if resultsToAdd := l.prepareResultToAdd(&result.Result); resultsToAdd {
+				if resultsToAdd := l.prepareResultToAdd(ctx, &result.Result); resultsToAdd {
 					if !sendToChanWithContext(ctx, addWordChan, result.Result) {
 						return
 					}
 				}
 			}
-			if !sendToChanWithContext(ctx, resultsChan, result.Result) {
+			if !sendToChanWithContext(ctx, resultsChan, result) {
 				return
 			}
 		}
@@ -381,22 +504,22 @@ func (l *Lingualeo) Process(ctx context.Context, words []string, wg *sync.WaitGr
 	}
 }
 
-func (l *Lingualeo) translateToChan(ctx context.Context, words []string) <-chan api.Result {
+func (l *Lingualeo) translateToChan(ctx context.Context, input <-chan Entry, size int) <-chan api.OperationResult {
 	var wg sync.WaitGroup
 	wg.Add(1)
-	channels := l.Process(ctx, words, &wg)
+	channels := l.process(ctx, input, size, &wg)
 	if l.Sound {
 		wg.Go(func() {
-			l.Pronounce(ctx, channels.sound, len(words))
+			l.Pronounce(ctx, channels.sound, size)
 		})
 	}
 	if l.Add {
 		wg.Go(func() {
-			l.AddToDictionary(ctx, channels.add, len(words))
+			l.AddToDictionary(ctx, channels.add, size)
 		})
 	}
 
-	ch := make(chan api.Result, len(words))
+	ch := make(chan api.OperationResult, size)
 
 	go func() {
 		defer close(ch)
@@ -411,32 +534,121 @@ func (l *Lingualeo) translateToChan(ctx context.Context, words []string) <-chan
 	return ch
 }
 
-func (l *Lingualeo) translateAndOutput(ctx context.Context, words []string, collectReverse bool) ([]string, error) {
-	englishWords := make([]string, 0, len(words))
-	for result := range channel.OrDone(ctx, l.translateToChan(ctx, words)) {
-		if err := l.Output(ctx, result); err != nil {
-			if errors.Is(err, context.Canceled) {
+// output outputs the result without interleaving with a Selector prompt.
+func (l *Lingualeo) output(ctx context.Context, result api.Result) error {
+	write := func() error { return l.Output(ctx, result) }
+	if preparing, ok := l.Outputer.(PreparingOutputer); ok {
+		write = preparing.Prepare(ctx, result)
+	}
+	console.Lock()
+	defer console.Unlock()
+	return write()
+}
+
+// outputError passes a failed translation to an ErrorOutputer.
+// It reports false when the outputer does not handle errors.
+func (l *Lingualeo) outputError(ctx context.Context, word string, err error) bool {
+	errorOutputer, ok := l.Outputer.(ErrorOutputer)
+	if !ok {
+		return false
+	}
+	console.Lock()
+	defer console.Unlock()
+	if outErr := errorOutputer.OutputError(ctx, word, err); outErr != nil {
+		slog.Error("cannot output translation error", "word", word, "error", outErr)
+	}
+	return true
+}
+
+// outputFailure outputs a word without a result to show: a failed translation,
+// a skipped word or a word without translations.
+func (l *Lingualeo) outputFailure(ctx context.Context, res api.OperationResult) {
+	word := res.Result.Word
+	if !errors.Is(res.Error, errExistingWord) && l.outputError(ctx, word, res.Error) {
+		return
+	}
+	console.Lock()
+	defer console.Unlock()
+	var err error
+	switch {
+	case errors.Is(res.Error, errExistingWord):
+		_ = messages.Message(messages.RED, "Skipped existing word: ")
+		err = messages.Message(messages.GREEN, "['%s']\n", word)
+	case errors.Is(res.Error, errNoTranslations):
+		_ = messages.Message(messages.RED, "There are no translations for word: ")
+		err = messages.Message(messages.GREEN, "['%s']\n", word)
+	default:
+		err = messages.Message(
+			messages.RED,
+			"%s\n",
+			cases.Title(language.Make(strings.ToLower(res.Error.Error()))),
+		)
+	}
+	if err != nil {
+		slog.Error("cannot show message", "error", err)
+	}
+}
+
+func (l *Lingualeo) translateAndOutput(ctx context.Context, input <-chan Entry, size int, collectReverse bool) ([]string, error) {
+	pair := l.languages()
+	reverse := make([]string, 0, size)
+	for res := range channel.OrDone(ctx, l.translateToChan(ctx, input, size)) {
+		if res.Error != nil {
+			l.outputFailure(ctx, res)
+			continue
+		}
+		result := res.Result
+		if err := l.output(ctx, result); err != nil {
+			switch {
+			case errors.Is(err, context.Canceled):
 				return nil, err
+			case errors.Is(err, errMediaDownload):
+				slog.Warn("output without media", "word", result.Word, "error", err)
+				l.report.record(result.Word, OutcomeDownloadFailed, err)
+			default:
+				slog.Error("cannot translate word", "word", result.Word, "error", err)
 			}
-			slog.Error("cannot translate word", "word", result.Word, "error", err)
 		}
-		for _, word := range result.Translate {
-			if collectReverse && isEnglishWord(word.Value) {
-				englishWords = append(englishWords, word.Value)
-			}
+		if collectReverse {
+			reverse = append(reverse, reverseWords(pair, result)...)
 		}
 	}
 
-	return slice.Unique(englishWords), nil
+	return slice.Unique(reverse), nil
 }
 
-func (l *Lingualeo) TranslateWithReverseRussian(ctx context.Context) {
-	englishWords, err := l.translateAndOutput(ctx, l.Words, l.ReverseTranslate)
-	if err != nil || len(englishWords) == 0 {
-		return
+// TranslateWithReverse translates the words and the InputFile entries and, with ReverseTranslate,
+// translates back the source language translations of target language words.
+// The input is streamed, so its size is unknown and it is not deduplicated.
+func (l *Lingualeo) TranslateWithReverse(ctx context.Context) error {
+	size := len(l.Words)
+	if l.InputFile != "" {
+		size = 0
+	}
+	entries, errc := l.entries(ctx)
+	reverse, err := l.translateAndOutput(ctx, entries, size, l.ReverseTranslate)
+	if err = errors.Join(err, <-errc); err != nil {
+		// An interrupted run is not a failure.
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		return err
 	}
-	_, err = l.translateAndOutput(ctx, englishWords, false)
-	if err != nil {
-		return
+	if len(reverse) == 0 {
+		return nil
+	}
+	_, err = l.translateAndOutput(ctx, channel.ToChannel(ctx, wordEntries(reverse)...), len(reverse), false)
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// TranslateWithReverseRussian translates the words with reverse translation.
+//
+// Deprecated: Use TranslateWithReverse instead.
+func (l *Lingualeo) TranslateWithReverseRussian(ctx context.Context) {
+	if err := l.TranslateWithReverse(ctx); err != nil {
+		slog.Error("cannot translate words", "error", err)
 	}
 }
@@ -38,6 +38,18 @@ func (c *blockingClient) Auth(_ context.Context) error {
 	return nil
 }
 
+func (*blockingClient) ListDictionary(_ context.Context, _ api.DictionaryQuery) (api.DictionaryPage, error) {
+	return api.DictionaryPage{}, nil
+}
+
+func (*blockingClient) DeleteWord(_ context.Context, word string) api.OperationResult {
+	return api.OperationResult{Result: api.Result{Word: word}}
+}
+
+func (*blockingClient) UpdateTranslation(_ context.Context, word string, translates []string) api.OperationResult {
+	return api.OperationResult{Result: api.Result{Word: word, AddWords: translates}}
+}
+
 func TestTranslateWordsStopsOnCancelWithoutConsumer(t *testing.T) {
 	t.Parallel()
 
@@ -48,8 +60,8 @@ func TestTranslateWordsStopsOnCancelWithoutConsumer(t *testing.T) {
 		addStarted:       make(chan struct{}),
 		addRelease:       make(chan struct{}),
 	}
-	words := make(chan string, 1)
-	words <- "word"
+	words := make(chan Entry, 1)
+	words <- Entry{Word: "word"}
 	close(words)
 
 	ch := translateWords(ctx, client, words, 1)
@@ -0,0 +1,54 @@
+package translator
+
+import (
+	"testing"
+
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/api/mock"
+	"github.com/trezorg/lingualeo/internal/channel"
+)
+
+func TestAddWordsSkipsExistingTranslations(t *testing.T) {
+	t.Parallel()
+
+	client := mock.NewMock_Client(t)
+	client.EXPECT().AddWord(testifymock.Anything, "hello", "привет").Return(api.OperationResult{}).Once()
+	result := api.Result{
+		Word:      "hello",
+		Translate: []api.Word{{Value: "привет"}, {Value: "здравствуй", Exists: true}},
+		AddWords:  []string{"привет", "здравствуй"},
+	}
+
+	var skipped []string
+	for res := range addWords(t.Context(), client, channel.ToChannel(t.Context(), result), 1) {
+		if res.Value.Error != nil {
+			require.ErrorIs(t, res.Value.Error, errTranslationExists)
+			skipped = append(skipped, res.Value.Result.AddWords...)
+		}
+	}
+	require.Equal(t, []string{"здравствуй"}, skipped)
+}
+
+func TestTranslateWithReverseDryRunDoesNotAdd(t *testing.T) {
+	t.Parallel()
+
+	// The mock fails the test on any AddWord call.
+	client := mock.NewMock_Client(t)
+	client.EXPECT().TranslateWord(testifymock.Anything, "hello").Return(api.OperationResult{
+		Result: api.Result{Word: "hello", Translate: []api.Word{{Value: "привет"}, {Value: "здравствуй", Exists: true}}},
+	}).Once()
+
+	output := &outputCollector{}
+	app := Lingualeo{
+		Client:   client,
+		Outputer: output,
+		Config:   Config{Add: true, DryRun: true},
+		Words:    []string{"hello"},
+	}
+
+	require.NoError(t, app.TranslateWithReverse(t.Context()))
+	require.Equal(t, []string{"hello"}, output.words)
+}
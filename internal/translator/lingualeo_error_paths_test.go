@@ -68,7 +68,7 @@ func TestCheckMediaPlayer(t *testing.T) {
 func TestOutputerReturnsUnknownVisualizeTypeError(t *testing.T) {
 	t.Parallel()
 
-	_, err := outputer(true, VisualiseType("broken"))
+	_, err := outputer(true, VisualiseType("broken"), nil)
 	require.Error(t, err)
 	require.True(t, errors.Is(err, errUnknownVisualiseType))
 }
@@ -0,0 +1,68 @@
+package translator
+
+import (
+	"slices"
+	"testing"
+
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/api/mock"
+)
+
+func TestTranslateWithReverseFiltersTranslations(t *testing.T) {
+	t.Parallel()
+
+	client := mock.NewMock_Client(t)
+	client.EXPECT().TranslateWord(testifymock.Anything, "hello").Return(api.OperationResult{
+		Result: api.Result{Word: "hello", Translate: []api.Word{
+			{Value: "привет", Votes: 100}, {Value: "здравствуй", Votes: 50}, {Value: "алло", Votes: 50}, {Value: "хелло", Votes: 2},
+		}},
+	}).Once()
+	client.EXPECT().TranslateWord(testifymock.Anything, "rare").Return(api.OperationResult{
+		Result: api.Result{Word: "rare", Translate: []api.Word{{Value: "редкий", Votes: 3}}},
+	}).Once()
+	client.EXPECT().TranslateWord(testifymock.Anything, "house").Return(api.OperationResult{
+		Result: api.Result{Word: "house", Exists: true, Translate: []api.Word{{Value: "дом", Votes: 100}}},
+	}).Once()
+	client.EXPECT().AddWord(testifymock.Anything, "hello", "привет").Return(api.OperationResult{}).Once()
+	client.EXPECT().AddWord(testifymock.Anything, "hello", "здравствуй").Return(api.OperationResult{}).Once()
+
+	output := &outputCollector{}
+	app := Lingualeo{
+		Client:   client,
+		Outputer: output,
+		Config:   Config{Add: true, Top: 2, MinVotes: 10, SkipExisting: true},
+		Words:    []string{"hello", "rare", "house"},
+	}
+
+	require.NoError(t, app.TranslateWithReverse(t.Context()))
+	slices.Sort(output.words)
+	require.Equal(t, []string{"hello"}, output.words)
+}
+
+func TestTranslateWithReverseDoesNotAddExistingTranslationsRemovedByFilters(t *testing.T) {
+	t.Parallel()
+
+	// The mock fails the test on an AddWord call for the existing translation.
+	client := mock.NewMock_Client(t)
+	client.EXPECT().TranslateWord(testifymock.Anything, "hello").Return(api.OperationResult{
+		Result: api.Result{Word: "hello", Translate: []api.Word{
+			{Value: "привет", Votes: 100}, {Value: "здравствуй", Votes: 5, Exists: true},
+		}},
+	}).Once()
+	client.EXPECT().AddWord(testifymock.Anything, "hello", "алло").Return(api.OperationResult{}).Once()
+
+	output := &outputCollector{}
+	app := Lingualeo{
+		Client:      client,
+		Outputer:    output,
+		Config:      Config{Add: true, Top: 1, MinVotes: 10},
+		Words:       []string{"hello"},
+		Translation: []string{"здравствуй", "алло"},
+	}
+
+	require.NoError(t, app.TranslateWithReverse(t.Context()))
+	require.Equal(t, []string{"hello"}, output.words)
+}
@@ -0,0 +1,147 @@
+package translator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json/v2"
+	"errors"
+	"strings"
+	"testing"
+
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/api/mock"
+	"github.com/trezorg/lingualeo/internal/channel"
+)
+
+func TestTranslateWithReverseKeepsInputOrder(t *testing.T) {
+	t.Parallel()
+
+	// The first word is translated after the others.
+	translated := make(chan struct{}, 2)
+	client := mock.NewMock_Client(t)
+	client.EXPECT().TranslateWord(testifymock.Anything, "first").RunAndReturn(func(ctx context.Context, word string) api.OperationResult {
+		for range 2 {
+			select {
+			case <-translated:
+			case <-ctx.Done():
+			}
+		}
+		return api.OperationResult{Result: api.Result{Word: word, Translate: []api.Word{{Value: "первый"}}}}
+	}).Once()
+	for _, word := range []string{"second", "third"} {
+		client.EXPECT().TranslateWord(testifymock.Anything, word).RunAndReturn(func(_ context.Context, word string) api.OperationResult {
+			translated <- struct{}{}
+			return api.OperationResult{Result: api.Result{Word: word, Translate: []api.Word{{Value: word}}}}
+		}).Once()
+	}
+
+	output := &outputCollector{}
+	app := Lingualeo{
+		Client:   client,
+		Outputer: output,
+		Config:   Config{Workers: 3, Order: AutoOrder},
+		Words:    []string{"first", "second", "third"},
+	}
+
+	require.NoError(t, app.TranslateWithReverse(t.Context()))
+	require.Equal(t, []string{"first", "second", "third"}, output.words)
+}
+
+func TestTranslateWithReverseKeepsInputOrderOfFailures(t *testing.T) {
+	t.Parallel()
+
+	// The first word is translated after the failing ones.
+	translated := make(chan struct{}, 3)
+	client := mock.NewMock_Client(t)
+	client.EXPECT().TranslateWord(testifymock.Anything, "first").RunAndReturn(func(ctx context.Context, word string) api.OperationResult {
+		for range 3 {
+			select {
+			case <-translated:
+			case <-ctx.Done():
+			}
+		}
+		return api.OperationResult{Result: api.Result{Word: word, Translate: []api.Word{{Value: "первый"}}}}
+	}).Once()
+	client.EXPECT().TranslateWord(testifymock.Anything, "broken").RunAndReturn(func(context.Context, string) api.OperationResult {
+		translated <- struct{}{}
+		return api.OperationResult{Error: errors.New("server error")}
+	}).Once()
+	client.EXPECT().TranslateWord(testifymock.Anything, "empty").RunAndReturn(func(_ context.Context, word string) api.OperationResult {
+		translated <- struct{}{}
+		return api.OperationResult{Result: api.Result{Word: word}}
+	}).Once()
+	client.EXPECT().TranslateWord(testifymock.Anything, "last").RunAndReturn(func(_ context.Context, word string) api.OperationResult {
+		translated <- struct{}{}
+		return api.OperationResult{Result: api.Result{Word: word, Translate: []api.Word{{Value: "последний"}}}}
+	}).Once()
+
+	buf := bytes.Buffer{}
+	output, err := NewRecordOutput(NDJSONFormat, &buf)
+	require.NoError(t, err)
+	app := Lingualeo{
+		Client:   client,
+		Outputer: output,
+		Config:   Config{Workers: 4, Order: InputOrder},
+		Words:    []string{"first", "broken", "empty", "last"},
+	}
+
+	require.NoError(t, app.TranslateWithReverse(t.Context()))
+	require.NoError(t, output.Close())
+	var records []Record
+	for line := range strings.Lines(buf.String()) {
+		var record Record
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	require.Len(t, records, 4)
+	require.Equal(t, []string{"first", "broken", "empty", "last"}, []string{
+		records[0].Word, records[1].Word, records[2].Word, records[3].Word,
+	})
+	require.Equal(t, "server error", records[1].Error)
+	require.Equal(t, errNoTranslations.Error(), records[2].Error)
+}
+
+func TestAddWordsInputOrder(t *testing.T) {
+	t.Parallel()
+
+	client := mock.NewMock_Client(t)
+	client.EXPECT().AddWord(testifymock.Anything, testifymock.Anything, testifymock.Anything).Return(api.OperationResult{})
+	input := channel.ToChannel(t.Context(),
+		api.Result{Word: "hello", AddWords: []string{"привет", "здравствуй"}},
+		api.Result{Word: "house", AddWords: []string{"дом"}},
+		api.Result{Word: "cat", Translate: []api.Word{{Value: "кот", Exists: true}}, AddWords: []string{"кот"}},
+	)
+
+	var added []string
+	for res := range inputOrder(t.Context(), addWords(t.Context(), client, input, 3), true) {
+		added = append(added, res.Value.Result.AddWords...)
+	}
+	require.Equal(t, []string{"привет", "здравствуй", "дом", "кот"}, added)
+}
+
+func TestConfigOrdered(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		order OutputOrder
+		size  int
+		want  bool
+	}{
+		{name: "auto small list", order: AutoOrder, size: 3, want: true},
+		{name: "auto long list", order: AutoOrder, size: orderedOutputLimit + 1, want: false},
+		{name: "auto streamed input", order: AutoOrder, size: 0, want: false},
+		{name: "input streamed input", order: InputOrder, size: 0, want: true},
+		{name: "completion small list", order: CompletionOrder, size: 3, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			config := Config{Order: tt.order}
+			require.Equal(t, tt.want, config.ordered(tt.size))
+		})
+	}
+}
@@ -36,6 +36,18 @@ func (*reverseClient) Auth(_ context.Context) error {
 	return nil
 }
 
+func (*reverseClient) ListDictionary(_ context.Context, _ api.DictionaryQuery) (api.DictionaryPage, error) {
+	return api.DictionaryPage{}, nil
+}
+
+func (*reverseClient) DeleteWord(_ context.Context, word string) api.OperationResult {
+	return api.OperationResult{Result: api.Result{Word: word}}
+}
+
+func (*reverseClient) UpdateTranslation(_ context.Context, word string, translates []string) api.OperationResult {
+	return api.OperationResult{Result: api.Result{Word: word, AddWords: translates}}
+}
+
 type outputCollector struct {
 	mu    sync.Mutex
 	words []string
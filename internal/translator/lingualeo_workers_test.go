@@ -44,6 +44,18 @@ func (*translateConcurrencyClient) Auth(_ context.Context) error {
 	return nil
 }
 
+func (*translateConcurrencyClient) ListDictionary(_ context.Context, _ api.DictionaryQuery) (api.DictionaryPage, error) {
+	return api.DictionaryPage{}, nil
+}
+
+func (*translateConcurrencyClient) DeleteWord(_ context.Context, word string) api.OperationResult {
+	return api.OperationResult{Result: api.Result{Word: word}}
+}
+
+func (*translateConcurrencyClient) UpdateTranslation(_ context.Context, word string, translates []string) api.OperationResult {
+	return api.OperationResult{Result: api.Result{Word: word, AddWords: translates}}
+}
+
 type addConcurrencyClient struct {
 	started chan struct{}
 	release chan struct{}
@@ -82,6 +94,18 @@ func (*addConcurrencyClient) Auth(_ context.Context) error {
 	return nil
 }
 
+func (*addConcurrencyClient) ListDictionary(_ context.Context, _ api.DictionaryQuery) (api.DictionaryPage, error) {
+	return api.DictionaryPage{}, nil
+}
+
+func (*addConcurrencyClient) DeleteWord(_ context.Context, word string) api.OperationResult {
+	return api.OperationResult{Result: api.Result{Word: word}}
+}
+
+func (*addConcurrencyClient) UpdateTranslation(_ context.Context, word string, translates []string) api.OperationResult {
+	return api.OperationResult{Result: api.Result{Word: word, AddWords: translates}}
+}
+
 func TestTranslateWordsRespectsWorkersLimit(t *testing.T) {
 	t.Parallel()
 
@@ -95,9 +119,9 @@ func TestTranslateWordsRespectsWorkersLimit(t *testing.T) {
 		started: make(chan struct{}, count),
 		release: make(chan struct{}),
 	}
-	words := make(chan string, count)
+	words := make(chan Entry, count)
 	for i := range count {
-		words <- "word"
+		words <- Entry{Word: "word"}
 		_ = i
 	}
 	close(words)
@@ -181,12 +205,12 @@ func assertNoAdditionalStart(t *testing.T, started <-chan struct{}) {
 	}
 }
 
-func collectResults(t *testing.T, ch <-chan api.OperationResult) []api.OperationResult {
+func collectResults[T any](t *testing.T, ch <-chan T) []T {
 	t.Helper()
 
-	done := make(chan []api.OperationResult, 1)
+	done := make(chan []T, 1)
 	go func() {
-		results := make([]api.OperationResult, 0)
+		results := make([]T, 0)
 		for res := range ch {
 			results = append(results, res)
 		}
@@ -0,0 +1,35 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/trezorg/lingualeo/internal/messages"
+)
+
+// ShowDictionary renders words from the user's dictionary through the outputer.
+// Only the requested page is shown unless AllPages is set.
+func (l *Lingualeo) ShowDictionary(ctx context.Context) error {
+	query := l.DictionaryQuery
+	shown := 0
+	for {
+		page, err := l.Client.ListDictionary(ctx, query)
+		if err != nil {
+			return err
+		}
+		for _, word := range page.Words {
+			if err = l.Output(ctx, word.Result()); err != nil {
+				if errors.Is(err, context.Canceled) {
+					return err
+				}
+				slog.Error("cannot show dictionary word", "word", word.Word, "error", err)
+			}
+		}
+		shown += len(page.Words)
+		if !l.AllPages || !page.HasMore() {
+			return messagef(messages.WHITE, "Shown %d of %d words\n", shown, page.Total)
+		}
+		query = page.NextQuery(query)
+	}
+}
@@ -0,0 +1,62 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trezorg/lingualeo/internal/api"
+	apimock "github.com/trezorg/lingualeo/internal/api/mock"
+)
+
+func dictionaryPage(page int, total int, words ...string) api.DictionaryPage {
+	res := api.DictionaryPage{Page: page, PerPage: 1, Total: total}
+	for _, word := range words {
+		res.Words = append(res.Words, api.DictionaryWord{Word: word})
+	}
+	return res
+}
+
+func TestShowDictionaryShowsRequestedPage(t *testing.T) {
+	t.Parallel()
+
+	client := apimock.NewMock_Client(t)
+	query := api.DictionaryQuery{Page: 2, PerPage: 1}
+	client.EXPECT().ListDictionary(t.Context(), query).Return(dictionaryPage(2, 3, "world"), nil).Once()
+	output := &outputCollector{}
+
+	app := Lingualeo{Client: client, Outputer: output, DictionaryQuery: query}
+	require.NoError(t, app.ShowDictionary(t.Context()))
+	require.Equal(t, []string{"world"}, output.words)
+}
+
+func TestShowDictionaryShowsAllPages(t *testing.T) {
+	t.Parallel()
+
+	client := apimock.NewMock_Client(t)
+	client.EXPECT().ListDictionary(t.Context(), mock.Anything).RunAndReturn(
+		func(_ context.Context, query api.DictionaryQuery) (api.DictionaryPage, error) {
+			words := []string{"hello", "world", "accommodation"}
+			page := max(query.Page, 1)
+			return dictionaryPage(page, len(words), words[page-1]), nil
+		}).Times(3)
+	output := &outputCollector{}
+
+	app := Lingualeo{Client: client, Outputer: output, AllPages: true}
+	require.NoError(t, app.ShowDictionary(t.Context()))
+	require.Equal(t, []string{"hello", "world", "accommodation"}, output.words)
+}
+
+func TestShowDictionaryReturnsClientError(t *testing.T) {
+	t.Parallel()
+
+	listErr := errors.New("list failed")
+	client := apimock.NewMock_Client(t)
+	client.EXPECT().ListDictionary(t.Context(), api.DictionaryQuery{}).Return(api.DictionaryPage{}, listErr).Once()
+
+	app := Lingualeo{Client: client, Outputer: &outputCollector{}}
+	require.ErrorIs(t, app.ShowDictionary(t.Context()), listErr)
+}
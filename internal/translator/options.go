@@ -30,6 +30,14 @@ func WithOutputer(o Outputer) Option {
 	}
 }
 
+// WithSelector sets the selector choosing translations to add.
+func WithSelector(s Selector) Option {
+	return func(l *Lingualeo) error {
+		l.Selector = s
+		return nil
+	}
+}
+
 func WithDownloader(d Downloader) Option {
 	return func(l *Lingualeo) error {
 		l.Downloader = d
@@ -43,3 +51,13 @@ func WithPronouncer(p Pronouncer) Option {
 		return nil
 	}
 }
+
+// WithMiddleware adds middlewares wrapping the API client created by Bootstrap.
+// They are applied in the given order around the built-in ones, so the first
+// middleware sees every call first.
+func WithMiddleware(middlewares ...api.Middleware) Option {
+	return func(l *Lingualeo) error {
+		l.middlewares = append(l.middlewares, middlewares...)
+		return nil
+	}
+}
@@ -0,0 +1,69 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/trezorg/lingualeo/internal/channel"
+)
+
+// OutputOrder selects whether results follow the input order or come as soon as
+// they are translated.
+type OutputOrder string
+
+const (
+	// AutoOrder keeps the input order for lists of up to orderedOutputLimit words.
+	// Streamed input of unknown size is printed in completion order.
+	AutoOrder OutputOrder = "auto"
+	// InputOrder outputs, adds and pronounces words in the input order.
+	InputOrder OutputOrder = "input"
+	// CompletionOrder outputs results as soon as they are translated.
+	CompletionOrder OutputOrder = "completion"
+)
+
+// orderedOutputLimit is the longest list ordered by AutoOrder. Longer lists
+// would wait for slow translations to print the following ones.
+const orderedOutputLimit = 100
+
+var (
+	OutputOrders = []OutputOrder{AutoOrder, InputOrder, CompletionOrder}
+
+	errOutputOrderAllowed = errors.New("allowed output orders")
+)
+
+func (o *OutputOrder) Set(value string) error {
+	order := OutputOrder(value)
+	for _, allowed := range OutputOrders {
+		if order == allowed {
+			*o = order
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %v", errOutputOrderAllowed, OutputOrders)
+}
+
+func (o *OutputOrder) String() string {
+	return string(*o)
+}
+
+// ordered reports whether size words are processed in the input order.
+// Size is zero when the number of words is unknown.
+func (c *Config) ordered(size int) bool {
+	switch c.Order {
+	case InputOrder:
+		return true
+	case CompletionOrder:
+		return false
+	default:
+		return size > 0 && size <= orderedOutputLimit
+	}
+}
+
+// inputOrder restores the input order of the indexed items when ordered is set.
+func inputOrder[T any](ctx context.Context, input <-chan channel.Indexed[T], ordered bool) <-chan channel.Indexed[T] {
+	if !ordered {
+		return input
+	}
+	return channel.Ordered(ctx, input, 0)
+}
@@ -40,6 +40,19 @@ func parseURL(s string) (*url.URL, error) {
 	return u, nil
 }
 
+// formatWordForms joins word forms into a single line, e.g. "went (past), gone (past participle)"
+func formatWordForms(forms []api.WordForm) string {
+	parts := make([]string, 0, len(forms))
+	for _, form := range forms {
+		if len(form.Type) == 0 {
+			parts = append(parts, form.Word)
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", form.Word, form.Type))
+	}
+	return strings.Join(parts, ", ")
+}
+
 func printTranslation(ctx context.Context, result api.Result) error {
 	var strTitle string
 	if result.InDictionary() {
@@ -50,9 +63,22 @@ func printTranslation(ctx context.Context, result api.Result) error {
 	if err := messagef(messages.RED, "Found %s word:\n", strTitle); err != nil {
 		return err
 	}
-	if err := messagef(messages.GREEN, "['%s'] (%s)\n", result.Word, result.Transcription); err != nil {
+	if err := messagef(messages.GREEN, "['%s'] (%s)", result.Word, result.Transcription); err != nil {
 		return err
 	}
+	if len(result.Pos) > 0 {
+		if err := messagef(messages.WHITE, " %s", result.Pos); err != nil {
+			return err
+		}
+	}
+	if err := messagef(messages.GREEN, "\n"); err != nil {
+		return err
+	}
+	if len(result.WordForms) > 0 {
+		if err := messagef(messages.WHITE, "Word forms: %s\n", formatWordForms(result.WordForms)); err != nil {
+			return err
+		}
+	}
 	for _, word := range result.Translate {
 		select {
 		case <-ctx.Done():
@@ -90,14 +116,25 @@ func (o OutputVisualizer) Output(ctx context.Context, result api.Result) error {
 		return err
 	}
 
-	var outErr error
+	pictures := make([]string, 0, len(result.Translate)+1)
 	for _, word := range result.Translate {
+		if len(word.Picture) > 0 {
+			pictures = append(pictures, word.Picture)
+		}
+	}
+	// Fall back to the top picture of the word when translations have none
+	if len(pictures) == 0 && len(result.Picture) > 0 {
+		pictures = append(pictures, result.Picture)
+	}
+
+	var outErr error
+	for _, picture := range pictures {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
 		}
-		u, err := parseURL(word.Picture)
+		u, err := parseURL(picture)
 		if err != nil {
 			outErr = errors.Join(outErr, err)
 			continue
@@ -127,3 +164,39 @@ func PrintAddedTranslation(result api.Result) error {
 
 	return messagef(messages.GREEN, "['%s'] ['%s']\n", result.Word, strings.Join(result.AddWords, ", "))
 }
+
+// PrintDryRunTranslation prints a translation that would be added without --dry-run
+func PrintDryRunTranslation(result api.Result) error {
+	if err := messagef(messages.RED, "Would add: "); err != nil {
+		return err
+	}
+
+	return messagef(messages.GREEN, "['%s'] ['%s']\n", result.Word, strings.Join(result.AddWords, ", "))
+}
+
+// PrintSkippedTranslation prints a translation not added as it is already in the dictionary
+func PrintSkippedTranslation(result api.Result) error {
+	if err := messagef(messages.RED, "Skipped existing: "); err != nil {
+		return err
+	}
+
+	return messagef(messages.GREEN, "['%s'] ['%s']\n", result.Word, strings.Join(result.AddWords, ", "))
+}
+
+// PrintDeletedWord prints a word removed from the dictionary
+func PrintDeletedWord(result api.Result) error {
+	if err := messagef(messages.RED, "Deleted word: "); err != nil {
+		return err
+	}
+
+	return messagef(messages.GREEN, "['%s']\n", result.Word)
+}
+
+// PrintUpdatedTranslation prints new translations of a word in the dictionary
+func PrintUpdatedTranslation(result api.Result) error {
+	if err := messagef(messages.RED, "Replaced translations: "); err != nil {
+		return err
+	}
+
+	return messagef(messages.GREEN, "['%s'] ['%s']\n", result.Word, strings.Join(result.AddWords, ", "))
+}
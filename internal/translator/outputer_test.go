@@ -0,0 +1,55 @@
+package translator
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trezorg/lingualeo/internal/api"
+)
+
+func TestFormatWordForms(t *testing.T) {
+	t.Parallel()
+
+	forms := []api.WordForm{
+		{Word: "went", Type: "past"},
+		{Word: "gone", Type: "past participle"},
+		{Word: "goes"},
+	}
+	require.Equal(t, "went (past), gone (past participle), goes", formatWordForms(forms))
+	require.Empty(t, formatWordForms(nil))
+}
+
+func TestOutputVisualizerShowsTranslationPictures(t *testing.T) {
+	t.Parallel()
+
+	viz := NewMock_Visualizer(t)
+	u, err := url.Parse("https://example.com/1.png")
+	require.NoError(t, err)
+	viz.EXPECT().Show(t.Context(), u).Return(nil).Once()
+
+	result := api.Result{
+		Word:      "hello",
+		Picture:   "https://example.com/top.png",
+		Translate: []api.Word{{Value: "привет", Picture: u.String()}, {Value: "алло"}},
+	}
+	require.NoError(t, OutputVisualizer{Visualizer: viz}.Output(t.Context(), result))
+}
+
+func TestOutputVisualizerFallsBackToTopPicture(t *testing.T) {
+	t.Parallel()
+
+	viz := NewMock_Visualizer(t)
+	u, err := url.Parse("https://example.com/top.png")
+	require.NoError(t, err)
+	viz.EXPECT().Show(t.Context(), u).Return(nil).Once()
+
+	result := api.Result{
+		Word:      "hello",
+		Picture:   u.String(),
+		WordForms: []api.WordForm{{Word: "hellos", Type: "pl."}},
+		Translate: []api.Word{{Value: "привет"}},
+	}
+	require.NoError(t, OutputVisualizer{Visualizer: viz}.Output(t.Context(), result))
+}
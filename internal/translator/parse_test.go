@@ -7,6 +7,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/lang"
 )
 
 func TestParseUsesConfigValuesWhenFlagsAreOmitted(t *testing.T) {
@@ -92,6 +95,392 @@ log_level = "WARN"
 	require.Equal(t, "WARN", client.LogLevel)
 }
 
+func TestParseAPIEndpoints(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+	writeConfig(t, "lingualeo.toml", `
+email = "config@example.com"
+password = "secret"
+api_url = "http://127.0.0.1:8080"
+`)
+
+	withArgs(t, []string{"lingualeo", "--add-word-url", "http://127.0.0.1:9090/add", "hello"})
+
+	client, err := Parse("test")
+	require.NoError(t, err)
+	endpoints := client.APIClientConfig().Endpoints
+	require.Equal(t, "http://127.0.0.1:8080/api/auth", endpoints.Auth)
+	require.Equal(t, "http://127.0.0.1:8080/getTranslates", endpoints.Translate)
+	require.Equal(t, "http://127.0.0.1:9090/add", endpoints.AddWord)
+}
+
+func TestParseRejectsInvalidAPIURL(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "-p", "secret", "--api-url", "ftp://mirror", "hello"})
+
+	_, err := Parse("test")
+	require.ErrorIs(t, err, errEndpointInvalid)
+}
+
+func TestParseDeleteCommand(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "-p", "secret", "delete", "hello", "world", "hello"})
+
+	client, err := Parse("test")
+	require.NoError(t, err)
+	require.Equal(t, CommandDelete, client.Command)
+	require.Equal(t, []string{"hello", "world"}, client.Words)
+}
+
+func TestParseEditCommand(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "-p", "secret", "edit", "-t", "привет", "-t", "алло", "hello"})
+
+	client, err := Parse("test")
+	require.NoError(t, err)
+	require.Equal(t, CommandEdit, client.Command)
+	require.Equal(t, []string{"hello"}, client.Words)
+	require.Equal(t, []string{"привет", "алло"}, client.Translation)
+}
+
+func TestParseEditCommandRequiresTranslation(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "-p", "secret", "edit", "hello"})
+
+	_, err := Parse("test")
+	require.ErrorIs(t, err, errEditTranslation)
+}
+
+func TestParseOfflineDoesNotRequirePassword(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+	t.Setenv("LINGUALEO_PASSWORD", "")
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "--offline", "--cache-ttl", "1h", "hello"})
+
+	client, err := Parse("test")
+	require.NoError(t, err)
+	require.True(t, client.Offline)
+	require.Equal(t, time.Hour, client.CacheTTL)
+}
+
+func TestParseRedactionListsAppendToConfig(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+	writeConfig(t, "lingualeo.toml", `
+email = "config@example.com"
+password = "secret"
+debug_redact_headers = ["X-Api-Key"]
+debug_redact_fields = ["secret"]
+`)
+
+	withArgs(t, []string{"lingualeo", "--redact-header", "X-Session", "--redact-field", "login,phone", "hello"})
+
+	client, err := Parse("test")
+	require.NoError(t, err)
+	require.Equal(t, []string{"X-Api-Key", "X-Session"}, client.DebugRedactHeaders)
+	require.Equal(t, []string{"secret", "login", "phone"}, client.DebugRedactFields)
+}
+
+func TestParseReplayDoesNotRequirePassword(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+	t.Setenv("LINGUALEO_PASSWORD", "")
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "--replay", "bug.yaml", "hello"})
+
+	client, err := Parse("test")
+	require.NoError(t, err)
+	require.Equal(t, "bug.yaml", client.Replay)
+}
+
+func TestParseRejectsRecordWithReplay(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "-p", "secret", "--record", "a.yaml", "--replay", "b.yaml", "hello"})
+
+	_, err := Parse("test")
+	require.ErrorIs(t, err, errRecordAndReplay)
+}
+
+func TestParseLanguages(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "-p", "secret", "-tl", "uk", "їжак"})
+
+	client, err := Parse("test")
+	require.NoError(t, err)
+	require.Equal(t, "en", client.SourceLanguage)
+	require.Equal(t, "uk", client.TargetLanguage)
+	require.Equal(t, lang.Pair{Source: lang.English, Target: lang.Ukrainian}, client.APIClientConfig().Languages)
+}
+
+func TestParseRejectsUnknownLanguage(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "-p", "secret", "--target-language", "xx", "hello"})
+
+	_, err := Parse("test")
+	require.Error(t, err)
+}
+
+func TestParseTranslationFilters(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+	writeConfig(t, "lingualeo.toml", `
+email = "config@example.com"
+password = "secret"
+min_votes = 5
+skip_existing = true
+`)
+
+	withArgs(t, []string{"lingualeo", "--top", "3", "hello"})
+
+	client, err := Parse("test")
+	require.NoError(t, err)
+	require.Equal(t, 3, client.Top)
+	require.Equal(t, 5, client.MinVotes)
+	require.True(t, client.SkipExisting)
+}
+
+func TestParseRejectsNegativeFilters(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "-p", "secret", "--min-votes", "-1", "hello"})
+
+	_, err := Parse("test")
+	require.ErrorIs(t, err, errNegativeFilter)
+}
+
+func TestParseOutputFormat(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "-p", "secret", "-o", "ndjson", "hello"})
+
+	client, err := Parse("test")
+	require.NoError(t, err)
+	require.Equal(t, NDJSONFormat, client.OutputFormat)
+}
+
+func TestParseRejectsUnknownOutputFormatInConfig(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+	writeConfig(t, "lingualeo.toml", `
+email = "config@example.com"
+password = "secret"
+output = "xml"
+`)
+
+	withArgs(t, []string{"lingualeo", "hello"})
+
+	_, err := Parse("test")
+	require.ErrorIs(t, err, errOutputFormatAllowed)
+}
+
+func TestParseOutputOrder(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "-p", "secret", "hello"})
+
+	client, err := Parse("test")
+	require.NoError(t, err)
+	require.Equal(t, AutoOrder, client.Order)
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "-p", "secret", "--order", "completion", "hello"})
+
+	client, err = Parse("test")
+	require.NoError(t, err)
+	require.Equal(t, CompletionOrder, client.Order)
+}
+
+func TestParseRejectsUnknownOutputOrderInConfig(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+	writeConfig(t, "lingualeo.toml", `
+email = "config@example.com"
+password = "secret"
+order = "random"
+`)
+
+	withArgs(t, []string{"lingualeo", "hello"})
+
+	_, err := Parse("test")
+	require.ErrorIs(t, err, errOutputOrderAllowed)
+}
+
+func TestParseSummaryFormat(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "-p", "secret", "--summary", "json", "hello"})
+
+	client, err := Parse("test")
+	require.NoError(t, err)
+	require.Equal(t, JSONSummary, client.Summary)
+}
+
+func TestParseRejectsUnknownSummaryFormatInConfig(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+	writeConfig(t, "lingualeo.toml", `
+email = "config@example.com"
+password = "secret"
+summary = "xml"
+`)
+
+	withArgs(t, []string{"lingualeo", "hello"})
+
+	_, err := Parse("test")
+	require.ErrorIs(t, err, errSummaryFormatAllowed)
+}
+
+func TestParseRejectsTemplateWithMachineReadableOutput(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "-p", "secret", "-o", "json", "--template", "{{ .Word }}", "hello"})
+
+	_, err := Parse("test")
+	require.ErrorIs(t, err, errTemplateFormat)
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		words []string
+		input string
+		add   bool
+	}{
+		{name: "stdin argument", args: []string{"hello", "-"}, words: []string{"hello"}, input: stdinInput},
+		{name: "input flag", args: []string{"--input", "words.txt"}, input: "words.txt"},
+		{name: "add input flag", args: []string{"-i", "words.txt", "add"}, input: "words.txt", add: true},
+		{name: "add stdin", args: []string{"add", "-"}, input: stdinInput, add: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTempHome(t)
+			t.Chdir(t.TempDir())
+
+			withArgs(t, append([]string{"lingualeo", "-e", "user@example.com", "-p", "secret"}, tt.args...))
+
+			client, err := Parse("test")
+			require.NoError(t, err)
+			require.Equal(t, tt.input, client.InputFile)
+			require.Equal(t, tt.add, client.Add)
+			if tt.words == nil {
+				require.Empty(t, client.Words)
+			} else {
+				require.Equal(t, tt.words, client.Words)
+			}
+		})
+	}
+}
+
+func TestParseRejectsInvalidInput(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		err  error
+	}{
+		{name: "delete", args: []string{"--input", "words.txt", "delete"}, err: errInputCommand},
+		{name: "custom translation", args: []string{"-i", "words.txt", "add", "-t", "привет"}, err: errAddCustomTranslation},
+		{name: "two inputs", args: []string{"--input", "words.txt", "-"}, err: errMultipleInputs},
+		{name: "select from stdin", args: []string{"add", "--select", "-"}, err: errSelectStdinInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTempHome(t)
+			t.Chdir(t.TempDir())
+
+			withArgs(t, append([]string{"lingualeo", "-e", "user@example.com", "-p", "secret"}, tt.args...))
+
+			_, err := Parse("test")
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestParseListCommand(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "-p", "secret",
+		"list", "--status", "learned", "--added-after", "2024-01-02", "--per-page", "20", "--all"})
+
+	client, err := Parse("test")
+	require.NoError(t, err)
+	require.Equal(t, CommandList, client.Command)
+	require.True(t, client.AllPages)
+	require.Equal(t, api.StatusLearned, client.DictionaryQuery.Status)
+	require.Equal(t, 20, client.DictionaryQuery.PerPage)
+	require.Equal(t, 1, client.DictionaryQuery.Page)
+	require.Equal(t, "2024-01-02", client.DictionaryQuery.AddedAfter.Format(time.DateOnly))
+	require.True(t, client.DictionaryQuery.AddedBefore.IsZero())
+}
+
+func TestParseReplCommand(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "-p", "secret", "repl", "--history-file", "words.history"})
+
+	client, err := Parse("test")
+	require.NoError(t, err)
+	require.Equal(t, CommandRepl, client.Command)
+	require.Equal(t, "words.history", client.HistoryFile)
+}
+
+func TestParseExportCommand(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "-p", "secret", "-i", "words.txt", "export", "--dir", "cards"})
+
+	client, err := Parse("test")
+	require.NoError(t, err)
+	require.Equal(t, CommandExport, client.Command)
+	require.Equal(t, AnkiFormat, client.ExportFormat)
+	require.Equal(t, "cards", client.ExportDir)
+	require.Equal(t, "words.txt", client.InputFile)
+}
+
+func TestParseExportRejectsUnknownFormat(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "-p", "secret", "export", "--format", "mnemosyne", "hello"})
+
+	_, err := Parse("test")
+	require.ErrorIs(t, err, errExportFormat)
+}
+
+func TestParseListCommandRejectsUnknownStatus(t *testing.T) {
+	useTempHome(t)
+	t.Chdir(t.TempDir())
+
+	withArgs(t, []string{"lingualeo", "-e", "user@example.com", "-p", "secret", "list", "--status", "forgotten"})
+
+	_, err := Parse("test")
+	require.Error(t, err)
+}
+
 func TestConfigFilesIncludeExplicitConfigLast(t *testing.T) {
 	t.Chdir(t.TempDir())
 	homeDir := useTempHome(t)
@@ -4,7 +4,7 @@ import (
 	"context"
 	"os/exec"
 
-	"github.com/trezorg/lingualeo/internal/player"
+	"github.com/trezorg/lingualeo/internal/command"
 )
 
 // Pronouncer interface
@@ -15,7 +15,7 @@ type Pronouncer interface {
 }
 
 func isCommandAvailable(name string) bool {
-	execName, _ := player.ParseCommand(name)
+	execName, _ := command.Parse(name)
 	if execName == "" {
 		return false
 	}
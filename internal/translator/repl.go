@@ -0,0 +1,345 @@
+package translator
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/channel"
+	"github.com/trezorg/lingualeo/internal/messages"
+	"github.com/trezorg/lingualeo/internal/player"
+
+	"golang.org/x/term"
+)
+
+const (
+	replPrompt         = "lingualeo> "
+	historyFileName    = "history"
+	defaultHistorySize = 1000
+)
+
+var (
+	errNothingToAdd       = errors.New("there is no translated word to add")
+	errTranslationNumber  = errors.New("invalid translation number")
+	errUnknownReplCommand = errors.New("unknown command, type :help")
+	errPicturesFormat     = errors.New("pictures are shown only with the default text output")
+)
+
+const replHelp = `Type a word or phrase to translate it. A word can be followed by tab separated translations.
+Commands:
+  :add        add the last word with all its translations
+  :add <n>    add the last word with its n-th translation
+  :sound      switch pronouncing on or off
+  :pic        switch showing pictures on or off
+  :reverse    switch reverse translation on or off
+  :history    show entered lines
+  :help       show this help
+  :quit       exit, as well as Ctrl-D
+`
+
+// history is a bounded line history kept in a file between sessions.
+// It implements term.History.
+type history struct {
+	entries []string // oldest first
+	size    int
+}
+
+// loadHistory reads the history file. A missing file gives an empty history.
+func loadHistory(filename string, size int) (*history, error) {
+	h := &history{size: size}
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read history: %w", err)
+	}
+	for line := range strings.SplitSeq(string(data), "\n") {
+		h.Add(line)
+	}
+	return h, nil
+}
+
+// Add appends an entry skipping blank lines and repeats of the last entry.
+func (h *history) Add(entry string) {
+	entry = strings.TrimSpace(entry)
+	if entry == "" || (len(h.entries) > 0 && h.entries[len(h.entries)-1] == entry) {
+		return
+	}
+	h.entries = append(h.entries, entry)
+	if h.size > 0 && len(h.entries) > h.size {
+		h.entries = h.entries[len(h.entries)-h.size:]
+	}
+}
+
+func (h *history) Len() int {
+	return len(h.entries)
+}
+
+// At returns the entry idx positions back, zero being the most recent one.
+func (h *history) At(idx int) string {
+	return h.entries[len(h.entries)-1-idx]
+}
+
+func (h *history) save(filename string) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0o700); err != nil {
+		return fmt.Errorf("create history directory: %w", err)
+	}
+	data := strings.Join(h.entries, "\n") + "\n"
+	if err := os.WriteFile(filename, []byte(data), 0o600); err != nil {
+		return fmt.Errorf("write history: %w", err)
+	}
+	return nil
+}
+
+func defaultHistoryFile() (string, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, historyFileName), nil
+}
+
+type lineReader interface {
+	ReadLine() (string, error)
+}
+
+// terminalReader edits lines in raw terminal mode. The terminal is switched
+// back between lines, so translations are printed as usual.
+type terminalReader struct {
+	terminal *term.Terminal
+	fd       int
+}
+
+func (r terminalReader) ReadLine() (string, error) {
+	state, err := term.MakeRaw(r.fd)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = term.Restore(r.fd, state) }()
+	return r.terminal.ReadLine()
+}
+
+// plainReader reads lines from non-terminal input, e.g. a pipe.
+type plainReader struct {
+	scanner *bufio.Scanner
+	history *history
+}
+
+func (r plainReader) ReadLine() (string, error) {
+	if !r.scanner.Scan() {
+		return "", cmp.Or(r.scanner.Err(), io.EOF)
+	}
+	line := r.scanner.Text()
+	r.history.Add(line)
+	return line, nil
+}
+
+func (l *Lingualeo) lineReader(h *history) lineReader {
+	if l.stdin == nil {
+		fd := int(os.Stdin.Fd()) //nolint:gosec // required by x/term API
+		if term.IsTerminal(fd) {
+			terminal := term.NewTerminal(struct {
+				io.Reader
+				io.Writer
+			}{os.Stdin, os.Stdout}, replPrompt)
+			terminal.History = h
+			return terminalReader{terminal: terminal, fd: fd}
+		}
+	}
+	stdin := l.stdin
+	if stdin == nil {
+		stdin = os.Stdin
+	}
+	return plainReader{scanner: bufio.NewScanner(stdin), history: h}
+}
+
+// repl keeps the state of an interactive session.
+type repl struct {
+	app     *Lingualeo
+	history *history
+	last    *api.Result
+}
+
+// Repl translates words read line by line until EOF or :quit.
+// Lines are edited in the terminal, the entered lines are kept in HistoryFile.
+func (l *Lingualeo) Repl(ctx context.Context) error {
+	filename := l.HistoryFile
+	if filename == "" {
+		var err error
+		if filename, err = defaultHistoryFile(); err != nil {
+			return err
+		}
+	}
+	h, err := loadHistory(filename, defaultHistorySize)
+	if err != nil {
+		return err
+	}
+	reader := l.lineReader(h)
+	r := &repl{app: l, history: h}
+	for ctx.Err() == nil {
+		line, err := reader.ReadLine()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read line: %w", err)
+		}
+		if err = h.save(filename); err != nil {
+			slog.Warn("cannot save history", "file", filename, "error", err)
+		}
+		quit, err := r.exec(ctx, line)
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		if err != nil {
+			if msgErr := messagef(messages.RED, "%v\n", err); msgErr != nil {
+				slog.Error("cannot show message", "error", msgErr)
+			}
+		}
+		if quit {
+			return nil
+		}
+	}
+	return nil
+}
+
+// exec runs a command or translates the line. It reports whether the session is over.
+func (r *repl) exec(ctx context.Context, line string) (bool, error) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, ":") {
+		entry, ok := parseEntry(line)
+		if !ok {
+			return false, nil
+		}
+		return false, r.translate(ctx, entry)
+	}
+	command, arg, _ := strings.Cut(line, " ")
+	arg = strings.TrimSpace(arg)
+	switch command {
+	case ":add", ":a":
+		return false, r.add(ctx, arg)
+	case ":sound", ":s":
+		return false, r.toggleSound()
+	case ":pic", ":p":
+		return false, r.togglePictures()
+	case ":reverse", ":r":
+		r.app.ReverseTranslate = !r.app.ReverseTranslate
+		return false, showSwitch("Reverse translation", r.app.ReverseTranslate)
+	case ":history", ":h":
+		return false, r.showHistory()
+	case ":help", ":?":
+		return false, messagef(messages.WHITE, "%s", replHelp)
+	case ":quit", ":q", ":exit":
+		return true, nil
+	default:
+		return false, fmt.Errorf("%w: %s", errUnknownReplCommand, command)
+	}
+}
+
+// translate outputs the translation of the entry and, with ReverseTranslate,
+// the translations of its reverse words. The entry result is kept for :add.
+func (r *repl) translate(ctx context.Context, entry Entry) error {
+	l := r.app
+	pair := l.languages()
+	var reverse []string
+	r.last = nil
+	for res := range channel.OrDone(ctx, l.translateToChan(ctx, channel.ToChannel(ctx, entry), 1)) {
+		if res.Error != nil {
+			l.outputFailure(ctx, res)
+			continue
+		}
+		result := res.Result
+		r.last = &result
+		if err := l.output(ctx, result); err != nil {
+			return err
+		}
+		if l.ReverseTranslate {
+			reverse = append(reverse, reverseWords(pair, result)...)
+		}
+	}
+	if len(reverse) > 0 {
+		if _, err := l.translateAndOutput(ctx, channel.ToChannel(ctx, wordEntries(reverse)...), len(reverse), false); err != nil {
+			return err
+		}
+	}
+	if flusher, ok := l.Outputer.(interface{ Flush() error }); ok {
+		if err := flusher.Flush(); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// add adds the last translated word with all translations or with the n-th one.
+func (r *repl) add(ctx context.Context, arg string) error {
+	if r.last == nil {
+		return errNothingToAdd
+	}
+	result := *r.last
+	if arg != "" {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 1 || n > len(result.Translate) {
+			return fmt.Errorf("%w: %s, expected 1-%d", errTranslationNumber, arg, len(result.Translate))
+		}
+		result.SetTranslation([]string{result.Translate[n-1].Value})
+	} else if !r.app.prepareResultToAdd(ctx, &result) {
+		return errNothingToAdd
+	}
+	r.app.AddToDictionary(ctx, channel.ToChannel(ctx, result), 1)
+	return ctx.Err()
+}
+
+func (r *repl) toggleSound() error {
+	l := r.app
+	l.Sound = !l.Sound
+	if l.Sound {
+		if err := l.checkMediaPlayer(); err != nil {
+			return err
+		}
+		if l.Pronouncer == nil {
+			l.Pronouncer = player.New(l.Player, player.WithShutdownTimeout(l.PlayerShutdownTimeout))
+		}
+	}
+	return showSwitch("Sound", l.Sound)
+}
+
+func (r *repl) togglePictures() error {
+	l := r.app
+	if !l.OutputFormat.isText() || l.usesTemplate() {
+		return errPicturesFormat
+	}
+	output, err := outputer(!l.Visualise, l.VisualiseType, l.httpClient)
+	if err != nil {
+		return err
+	}
+	l.Visualise = !l.Visualise
+	l.Outputer = output
+	return showSwitch("Pictures", l.Visualise)
+}
+
+func (r *repl) showHistory() error {
+	for i, entry := range r.history.entries {
+		if err := messagef(messages.WHITE, "%4d  %s\n", i+1, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func showSwitch(name string, on bool) error {
+	state := "off"
+	if on {
+		state = "on"
+	}
+	return messagef(messages.YELLOW, "%s %s\n", name, state)
+}
@@ -0,0 +1,99 @@
+package translator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/api/mock"
+)
+
+func TestHistoryIsBoundedAndSkipsRepeats(t *testing.T) {
+	h := &history{size: 3}
+	for _, entry := range []string{"one", " ", "two", "two", "three", "four"} {
+		h.Add(entry)
+	}
+
+	require.Equal(t, 3, h.Len())
+	require.Equal(t, "four", h.At(0))
+	require.Equal(t, "two", h.At(2))
+}
+
+func TestHistoryPersistsBetweenSessions(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "state", "history")
+	h, err := loadHistory(filename, defaultHistorySize)
+	require.NoError(t, err)
+	require.Zero(t, h.Len())
+
+	h.Add("hello")
+	h.Add(":add 2")
+	require.NoError(t, h.save(filename))
+
+	restored, err := loadHistory(filename, defaultHistorySize)
+	require.NoError(t, err)
+	require.Equal(t, []string{"hello", ":add 2"}, restored.entries)
+}
+
+func TestReplTranslatesAndAddsChosenTranslation(t *testing.T) {
+	client := mock.NewMock_Client(t)
+	client.EXPECT().TranslateWord(testifymock.Anything, "hello").Return(api.OperationResult{
+		Result: api.Result{Word: "hello", Translate: []api.Word{{Value: "привет"}, {Value: "здравствуй"}}},
+	}).Once()
+	client.EXPECT().TranslateWord(testifymock.Anything, "world").Return(api.OperationResult{
+		Result: api.Result{Word: "world", Translate: []api.Word{{Value: "мир"}}},
+	}).Once()
+	client.EXPECT().AddWord(testifymock.Anything, "hello", "здравствуй").Return(api.OperationResult{}).Once()
+	client.EXPECT().AddWord(testifymock.Anything, "world", "мир").Return(api.OperationResult{}).Once()
+
+	historyFile := filepath.Join(t.TempDir(), "history")
+	output := &outputCollector{}
+	app := Lingualeo{
+		Client:   client,
+		Outputer: output,
+		Config:   Config{HistoryFile: historyFile},
+		Command:  CommandRepl,
+		stdin:    strings.NewReader("hello\n:add 5\n:add 2\n:unknown\nworld\n:add\n:quit\nignored\n"),
+	}
+
+	require.NoError(t, app.Run(t.Context()))
+	require.Equal(t, []string{"hello", "world"}, output.words)
+
+	data, err := os.ReadFile(historyFile)
+	require.NoError(t, err)
+	require.Equal(t, "hello\n:add 5\n:add 2\n:unknown\nworld\n:add\n:quit\n", string(data))
+}
+
+func TestReplTogglesReverseTranslation(t *testing.T) {
+	client := mock.NewMock_Client(t)
+	client.EXPECT().TranslateWord(testifymock.Anything, "мир").Return(api.OperationResult{
+		Result: api.Result{Word: "мир", Translate: []api.Word{{Value: "world"}}},
+	}).Twice()
+	client.EXPECT().TranslateWord(testifymock.Anything, "world").Return(api.OperationResult{
+		Result: api.Result{Word: "world", Translate: []api.Word{{Value: "мир"}}},
+	}).Once()
+
+	output := &outputCollector{}
+	app := Lingualeo{
+		Client:   client,
+		Outputer: output,
+		Config:   Config{HistoryFile: filepath.Join(t.TempDir(), "history")},
+		stdin:    strings.NewReader("мир\n:reverse\nмир\n"),
+	}
+
+	require.NoError(t, app.Repl(t.Context()))
+	require.True(t, app.ReverseTranslate)
+	require.Equal(t, []string{"мир", "мир", "world"}, output.words)
+}
+
+func TestReplAddWithoutTranslatedWord(t *testing.T) {
+	r := &repl{app: &Lingualeo{}, history: &history{}}
+
+	quit, err := r.exec(t.Context(), ":add")
+	require.False(t, quit)
+	require.ErrorIs(t, err, errNothingToAdd)
+}
@@ -0,0 +1,269 @@
+package translator
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/trezorg/lingualeo/internal/messages"
+)
+
+// Outcome is what happened to a word during a run.
+type Outcome string
+
+const (
+	OutcomeTranslated      Outcome = "translated"
+	OutcomeNoTranslation   Outcome = "no_translation"
+	OutcomeTranslateFailed Outcome = "translate_failed"
+	OutcomeSkipped         Outcome = "skipped"
+	OutcomeAdded           Outcome = "added"
+	OutcomeAddFailed       Outcome = "add_failed"
+	OutcomePlayed          Outcome = "played"
+	OutcomePlayFailed      Outcome = "play_failed"
+	OutcomeDownloadFailed  Outcome = "download_failed"
+	OutcomeDeleted         Outcome = "deleted"
+	OutcomeDeleteFailed    Outcome = "delete_failed"
+	OutcomeUpdated         Outcome = "updated"
+	OutcomeUpdateFailed    Outcome = "update_failed"
+)
+
+// outcomes is the order of outcomes in the summary.
+var outcomes = []Outcome{
+	OutcomeTranslated, OutcomeNoTranslation, OutcomeTranslateFailed, OutcomeSkipped,
+	OutcomeAdded, OutcomeAddFailed, OutcomePlayed, OutcomePlayFailed, OutcomeDownloadFailed,
+	OutcomeDeleted, OutcomeDeleteFailed, OutcomeUpdated, OutcomeUpdateFailed,
+}
+
+// failed reports whether the word was not fully processed.
+func (o Outcome) failed() bool {
+	switch o {
+	case OutcomeNoTranslation, OutcomeTranslateFailed, OutcomeAddFailed, OutcomePlayFailed, OutcomeDownloadFailed,
+		OutcomeDeleteFailed, OutcomeUpdateFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// RunStatus sums up the outcomes of all words.
+type RunStatus string
+
+const (
+	// StatusSuccess means no word failed.
+	StatusSuccess RunStatus = "success"
+	// StatusPartialFailure means some words failed.
+	StatusPartialFailure RunStatus = "partial_failure"
+	// StatusFailure means every word failed.
+	StatusFailure RunStatus = "failure"
+)
+
+// Exit codes of the command line tool.
+const (
+	ExitSuccess = 0
+	// ExitError is returned when the command cannot run, e.g. authentication fails.
+	ExitError = 1
+	// ExitPartialFailure is returned when some words failed.
+	ExitPartialFailure = 2
+	// ExitFailure is returned when every word failed.
+	ExitFailure = 3
+)
+
+// SummaryFormat selects how the report is printed at the end of a run.
+type SummaryFormat string
+
+const (
+	// NoSummary prints nothing.
+	NoSummary SummaryFormat = "none"
+	// TextSummary prints the counts of outcomes and the failed words.
+	TextSummary SummaryFormat = "text"
+	// JSONSummary prints the Summary as JSON.
+	JSONSummary SummaryFormat = "json"
+)
+
+var (
+	SummaryFormats = []SummaryFormat{NoSummary, TextSummary, JSONSummary}
+
+	errSummaryFormatAllowed = errors.New("allowed summary formats")
+)
+
+func (f *SummaryFormat) Set(value string) error {
+	format := SummaryFormat(value)
+	for _, allowed := range SummaryFormats {
+		if format == allowed {
+			*f = format
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %v", errSummaryFormatAllowed, SummaryFormats)
+}
+
+func (f *SummaryFormat) String() string {
+	return string(*f)
+}
+
+// Failure is a failed step of a word.
+type Failure struct {
+	Word    string  `json:"word"`
+	Outcome Outcome `json:"outcome"`
+	Error   string  `json:"error,omitempty"`
+}
+
+// Summary is the serialized report.
+type Summary struct {
+	Status   RunStatus       `json:"status"`
+	Words    int             `json:"words"`
+	Failed   int             `json:"failed"`
+	Outcomes map[Outcome]int `json:"outcomes"`
+	Failures []Failure       `json:"failures"`
+}
+
+// Report collects the outcomes of words during a run. A nil report records nothing.
+type Report struct {
+	counts   map[Outcome]int
+	words    map[string]bool // word -> failed
+	sounds   map[string]string
+	failures []Failure
+	mu       sync.Mutex
+}
+
+// NewReport creates an empty report.
+func NewReport() *Report {
+	return &Report{
+		counts: make(map[Outcome]int),
+		words:  make(map[string]bool),
+		sounds: make(map[string]string),
+	}
+}
+
+// record records the outcome of the word. Err describes a failure.
+func (r *Report) record(word string, outcome Outcome, err error) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counts[outcome]++
+	r.words[word] = r.words[word] || outcome.failed()
+	if !outcome.failed() {
+		return
+	}
+	failure := Failure{Word: word, Outcome: outcome}
+	if err != nil {
+		failure.Error = err.Error()
+	}
+	r.failures = append(r.failures, failure)
+}
+
+// addSound remembers the word of a sound URL, so playback is reported per word.
+func (r *Report) addSound(url string, word string) {
+	if r == nil || url == "" {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sounds[url] = word
+}
+
+// recordSound records the outcome of playing the URL. Unknown URLs are ignored.
+func (r *Report) recordSound(url string, outcome Outcome, err error) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	word, ok := r.sounds[url]
+	r.mu.Unlock()
+	if ok {
+		r.record(word, outcome, err)
+	}
+}
+
+// Summary returns the counts of outcomes and the failures.
+func (r *Report) Summary() Summary {
+	summary := Summary{Status: StatusSuccess, Outcomes: map[Outcome]int{}, Failures: []Failure{}}
+	if r == nil {
+		return summary
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, outcome := range outcomes {
+		summary.Outcomes[outcome] = r.counts[outcome]
+	}
+	summary.Failures = append(summary.Failures, r.failures...)
+	summary.Words = len(r.words)
+	for _, failed := range r.words {
+		if failed {
+			summary.Failed++
+		}
+	}
+	switch {
+	case summary.Failed == 0:
+	case summary.Failed == summary.Words:
+		summary.Status = StatusFailure
+	default:
+		summary.Status = StatusPartialFailure
+	}
+	return summary
+}
+
+// ExitCode returns the exit code matching the status of the run.
+func (r *Report) ExitCode() int {
+	switch r.Summary().Status {
+	case StatusFailure:
+		return ExitFailure
+	case StatusPartialFailure:
+		return ExitPartialFailure
+	default:
+		return ExitSuccess
+	}
+}
+
+// Print writes the summary in the format into w.
+func (r *Report) Print(w io.Writer, format SummaryFormat) error {
+	summary := r.Summary()
+	switch format {
+	case TextSummary:
+		return printTextSummary(w, summary)
+	case JSONSummary:
+		data, err := json.Marshal(summary, json.Deterministic(true), jsontext.WithIndent("  "))
+		if err != nil {
+			return fmt.Errorf("encode summary: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	default:
+		return nil
+	}
+}
+
+func printTextSummary(w io.Writer, summary Summary) error {
+	counts := make([]string, 0, len(outcomes))
+	for _, outcome := range outcomes {
+		if count := summary.Outcomes[outcome]; count > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", count, strings.ReplaceAll(string(outcome), "_", " ")))
+		}
+	}
+	if len(counts) == 0 {
+		counts = append(counts, "nothing done")
+	}
+	c := messages.GREEN
+	if summary.Status != StatusSuccess {
+		c = messages.RED
+	}
+	if err := messages.MessageTo(w, c, "Summary: %s\n", strings.Join(counts, ", ")); err != nil {
+		return err
+	}
+	for _, failure := range summary.Failures {
+		message := strings.ReplaceAll(string(failure.Outcome), "_", " ")
+		if failure.Error != "" {
+			message += ": " + failure.Error
+		}
+		if err := messages.MessageTo(w, messages.RED, "Failed: ['%s'] %s\n", failure.Word, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package translator
+
+import (
+	"bytes"
+	"encoding/json/v2"
+	"errors"
+	"testing"
+
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/api/mock"
+)
+
+func TestReportStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		outcomes map[string][]Outcome
+		status   RunStatus
+		exitCode int
+	}{
+		{name: "no words", status: StatusSuccess, exitCode: ExitSuccess},
+		{
+			name:     "all words succeeded",
+			outcomes: map[string][]Outcome{"hello": {OutcomeTranslated, OutcomeAdded}, "house": {OutcomeSkipped}},
+			status:   StatusSuccess,
+			exitCode: ExitSuccess,
+		},
+		{
+			name:     "some words failed",
+			outcomes: map[string][]Outcome{"hello": {OutcomeTranslated, OutcomeAddFailed}, "house": {OutcomeTranslated}},
+			status:   StatusPartialFailure,
+			exitCode: ExitPartialFailure,
+		},
+		{
+			name:     "every word failed",
+			outcomes: map[string][]Outcome{"hello": {OutcomeTranslateFailed}, "xyz": {OutcomeNoTranslation}},
+			status:   StatusFailure,
+			exitCode: ExitFailure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			report := NewReport()
+			for word, outcomes := range tt.outcomes {
+				for _, outcome := range outcomes {
+					report.record(word, outcome, nil)
+				}
+			}
+			require.Equal(t, tt.status, report.Summary().Status)
+			require.Equal(t, tt.exitCode, report.ExitCode())
+		})
+	}
+}
+
+func TestNilReportRecordsNothing(t *testing.T) {
+	t.Parallel()
+
+	var report *Report
+	report.record("hello", OutcomeAddFailed, errors.New("failed"))
+	report.addSound("https://example.com/hello.mp3", "hello")
+	report.recordSound("https://example.com/hello.mp3", OutcomePlayed, nil)
+	require.Equal(t, StatusSuccess, report.Summary().Status)
+	require.Equal(t, ExitSuccess, report.ExitCode())
+}
+
+func TestReportPrint(t *testing.T) {
+	t.Parallel()
+
+	report := NewReport()
+	report.record("hello", OutcomeTranslated, nil)
+	report.record("hello", OutcomeAddFailed, errors.New("server error"))
+	report.addSound("https://example.com/hello.mp3", "hello")
+	report.recordSound("https://example.com/hello.mp3", OutcomePlayed, nil)
+	report.recordSound("https://example.com/unknown.mp3", OutcomePlayFailed, nil)
+	report.record("house", OutcomeTranslated, nil)
+
+	text := bytes.Buffer{}
+	require.NoError(t, report.Print(&text, TextSummary))
+	require.Equal(t,
+		"Summary: 2 translated, 1 add failed, 1 played\nFailed: ['hello'] add failed: server error\n",
+		text.String())
+
+	data := bytes.Buffer{}
+	require.NoError(t, report.Print(&data, JSONSummary))
+	var summary Summary
+	require.NoError(t, json.Unmarshal(data.Bytes(), &summary))
+	require.Equal(t, StatusPartialFailure, summary.Status)
+	require.Equal(t, 2, summary.Words)
+	require.Equal(t, 1, summary.Failed)
+	require.Equal(t, 1, summary.Outcomes[OutcomePlayed])
+	require.Equal(t, []Failure{{Word: "hello", Outcome: OutcomeAddFailed, Error: "server error"}}, summary.Failures)
+
+	none := bytes.Buffer{}
+	require.NoError(t, report.Print(&none, NoSummary))
+	require.Empty(t, none.String())
+}
+
+func TestRunReportsOutcomes(t *testing.T) {
+	t.Parallel()
+
+	client := mock.NewMock_Client(t)
+	client.EXPECT().TranslateWord(testifymock.Anything, "hello").Return(api.OperationResult{
+		Result: api.Result{Word: "hello", Translate: []api.Word{{Value: "привет"}}},
+	}).Once()
+	client.EXPECT().TranslateWord(testifymock.Anything, "house").Return(api.OperationResult{
+		Result: api.Result{Word: "house", Translate: []api.Word{{Value: "дом"}}},
+	}).Once()
+	client.EXPECT().TranslateWord(testifymock.Anything, "broken").Return(api.OperationResult{
+		Error: errors.New("server error"),
+	}).Once()
+	client.EXPECT().AddWord(testifymock.Anything, "hello", "привет").Return(api.OperationResult{
+		Result: api.Result{Word: "hello"},
+	}).Once()
+	client.EXPECT().AddWord(testifymock.Anything, "house", "дом").Return(api.OperationResult{
+		Error: errors.New("server error"),
+	}).Once()
+
+	app := Lingualeo{
+		Client:   client,
+		Outputer: &outputCollector{},
+		Config:   Config{Add: true},
+		Words:    []string{"hello", "house", "broken"},
+	}
+
+	require.NoError(t, app.Run(t.Context()))
+	summary := app.Report().Summary()
+	require.Equal(t, StatusPartialFailure, summary.Status)
+	require.Equal(t, 3, summary.Words)
+	require.Equal(t, 2, summary.Failed)
+	require.Equal(t, 2, summary.Outcomes[OutcomeTranslated])
+	require.Equal(t, 1, summary.Outcomes[OutcomeTranslateFailed])
+	require.Equal(t, 1, summary.Outcomes[OutcomeAdded])
+	require.Equal(t, 1, summary.Outcomes[OutcomeAddFailed])
+	require.ElementsMatch(t, []Failure{
+		{Word: "broken", Outcome: OutcomeTranslateFailed, Error: "server error"},
+		{Word: "house", Outcome: OutcomeAddFailed, Error: "server error"},
+	}, summary.Failures)
+	require.Equal(t, ExitPartialFailure, app.Report().ExitCode())
+}
+
+func TestRunReportsDeletedWords(t *testing.T) {
+	t.Parallel()
+
+	client := mock.NewMock_Client(t)
+	client.EXPECT().DeleteWord(testifymock.Anything, "hello").Return(api.OperationResult{
+		Result: api.Result{Word: "hello"},
+	}).Once()
+	client.EXPECT().DeleteWord(testifymock.Anything, "world").Return(api.OperationResult{
+		Error: errors.New("not found"),
+	}).Once()
+
+	app := Lingualeo{Client: client, Words: []string{"hello", "world"}, Command: CommandDelete}
+
+	require.NoError(t, app.Run(t.Context()))
+	summary := app.Report().Summary()
+	require.Equal(t, 1, summary.Outcomes[OutcomeDeleted])
+	require.Equal(t, []Failure{{Word: "world", Outcome: OutcomeDeleteFailed, Error: "not found"}}, summary.Failures)
+	require.Equal(t, ExitPartialFailure, app.Report().ExitCode())
+}
+
+func TestRunReportsUpdatedWords(t *testing.T) {
+	t.Parallel()
+
+	translations := []string{"привет"}
+	client := mock.NewMock_Client(t)
+	client.EXPECT().UpdateTranslation(testifymock.Anything, "hello", translations).Return(api.OperationResult{
+		Error: errors.New("server error"),
+	}).Once()
+	client.EXPECT().UpdateTranslation(testifymock.Anything, "world", translations).Return(api.OperationResult{
+		Error: errors.New("server error"),
+	}).Once()
+
+	app := Lingualeo{Client: client, Words: []string{"hello", "world"}, Translation: translations, Command: CommandEdit}
+
+	require.NoError(t, app.Run(t.Context()))
+	summary := app.Report().Summary()
+	require.Equal(t, 2, summary.Outcomes[OutcomeUpdateFailed])
+	require.Equal(t, ExitFailure, app.Report().ExitCode())
+
+	client.EXPECT().UpdateTranslation(testifymock.Anything, "hello", translations).Return(api.OperationResult{
+		Result: api.Result{Word: "hello", AddWords: translations},
+	}).Once()
+	app.Words = []string{"hello"}
+	require.NoError(t, app.Run(t.Context()))
+	require.Equal(t, 1, app.Report().Summary().Outcomes[OutcomeUpdated])
+	require.Equal(t, ExitSuccess, app.Report().ExitCode())
+}
+
+func TestRunReportsFailedExportMedia(t *testing.T) {
+	t.Parallel()
+
+	soundURL := "https://example.com/sound/hello.mp3"
+	client := mock.NewMock_Client(t)
+	client.EXPECT().TranslateWord(testifymock.Anything, "hello").Return(api.OperationResult{
+		Result: api.Result{Word: "hello", SoundURL: soundURL, Translate: []api.Word{{Value: "привет"}}},
+	}).Once()
+	downloader := NewMock_Downloader(t)
+	downloader.EXPECT().Download(testifymock.Anything, soundURL).Return("", errors.New("not found")).Once()
+	export, err := NewAnkiExport(t.TempDir(), downloader)
+	require.NoError(t, err)
+
+	app := Lingualeo{Client: client, Outputer: export, Words: []string{"hello"}, Command: CommandExport}
+
+	require.NoError(t, app.Run(t.Context()))
+	summary := app.Report().Summary()
+	require.Equal(t, 1, summary.Outcomes[OutcomeTranslated])
+	require.Equal(t, 1, summary.Outcomes[OutcomeDownloadFailed])
+	require.Equal(t, ExitFailure, app.Report().ExitCode())
+}
@@ -0,0 +1,155 @@
+package translator
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/messages"
+
+	"golang.org/x/term"
+)
+
+const skipSelection = "-"
+
+var (
+	errSelectNonTTY     = errors.New("cannot select translations from non-terminal stdin")
+	errSelectStdinInput = errors.New("translations cannot be selected while words are read from stdin")
+)
+
+// console serializes interactive prompts with translation output,
+// so a prompt does not interleave with the output of another word.
+var console sync.Mutex
+
+// Selector picks the translations of a word to add into the dictionary.
+type Selector interface {
+	// Select returns the chosen translations. No translations mean the word is skipped.
+	Select(ctx context.Context, result api.Result) ([]string, error)
+}
+
+// PromptSelector prints numbered translations with votes and reads the choice:
+// numbers separated by commas or spaces, an empty line for the top translation,
+// "-" to skip the word, or any other text as comma separated custom translations.
+type PromptSelector struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// NewPromptSelector creates a selector reading choices from in and prompting to out.
+func NewPromptSelector(in io.Reader, out io.Writer) *PromptSelector {
+	return &PromptSelector{in: bufio.NewReader(in), out: out}
+}
+
+// newTerminalSelector creates a selector on the terminal stdin.
+func newTerminalSelector() (*PromptSelector, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) { //nolint:gosec // required by x/term API
+		return nil, errSelectNonTTY
+	}
+	return NewPromptSelector(os.Stdin, os.Stdout), nil
+}
+
+func (s *PromptSelector) Select(ctx context.Context, result api.Result) ([]string, error) {
+	console.Lock()
+	defer console.Unlock()
+
+	if err := s.printChoices(result); err != nil {
+		return nil, err
+	}
+	for {
+		line, err := s.readLine(ctx)
+		if err != nil {
+			return nil, err
+		}
+		translations, err := parseSelection(line, result.Translate)
+		if err == nil {
+			return translations, nil
+		}
+		if err = messages.MessageTo(s.out, messages.RED, "%v, try again: ", err); err != nil {
+			return nil, fmt.Errorf("%w: %w", errShowMessage, err)
+		}
+	}
+}
+
+func (s *PromptSelector) printChoices(result api.Result) error {
+	if err := messages.MessageTo(s.out, messages.GREEN, "Translations of ['%s']:\n", result.Word); err != nil {
+		return fmt.Errorf("%w: %w", errShowMessage, err)
+	}
+	for i, word := range result.Translate {
+		if err := messages.MessageTo(s.out, messages.YELLOW, "%3d. %s", i+1, word.Value); err != nil {
+			return fmt.Errorf("%w: %w", errShowMessage, err)
+		}
+		if err := messages.MessageTo(s.out, messages.WHITE, " (%d votes)\n", word.Votes); err != nil {
+			return fmt.Errorf("%w: %w", errShowMessage, err)
+		}
+	}
+	err := messages.MessageTo(s.out, messages.WHITE, "Numbers to add, Enter for the top one, %s to skip, or your own translation: ", skipSelection)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errShowMessage, err)
+	}
+	return nil
+}
+
+// readLine reads a line unless the context is cancelled first.
+func (s *PromptSelector) readLine(ctx context.Context) (string, error) {
+	type line struct {
+		err  error
+		text string
+	}
+	lines := make(chan line, 1)
+	go func() {
+		text, err := s.in.ReadString('\n')
+		if errors.Is(err, io.EOF) && text != "" {
+			err = nil
+		}
+		lines <- line{text: text, err: err}
+	}()
+	select {
+	case <-ctx.Done():
+		return "", context.Cause(ctx)
+	case l := <-lines:
+		return l.text, l.err
+	}
+}
+
+// parseSelection converts a choice into translations, see PromptSelector.
+func parseSelection(line string, words []api.Word) ([]string, error) {
+	line = strings.TrimSpace(line)
+	switch {
+	case line == skipSelection:
+		return nil, nil
+	case line == "" && len(words) > 0:
+		return []string{words[0].Value}, nil
+	case line == "":
+		return nil, errTranslationNumber
+	}
+	fields := strings.FieldsFunc(line, func(r rune) bool { return r == ',' || r == ' ' })
+	translations := make([]string, 0, len(fields))
+	for _, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return customSelection(line), nil
+		}
+		if n < 1 || n > len(words) {
+			return nil, fmt.Errorf("%w: %d, expected 1-%d", errTranslationNumber, n, len(words))
+		}
+		translations = append(translations, words[n-1].Value)
+	}
+	return translations, nil
+}
+
+func customSelection(line string) []string {
+	var translations []string
+	for item := range strings.SplitSeq(line, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			translations = append(translations, item)
+		}
+	}
+	return translations
+}
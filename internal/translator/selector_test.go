@@ -0,0 +1,82 @@
+package translator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/api/mock"
+)
+
+var selectionWords = []api.Word{{Value: "привет", Votes: 10}, {Value: "здравствуй", Votes: 5}, {Value: "салют", Votes: 1}}
+
+func TestParseSelection(t *testing.T) {
+	tests := []struct {
+		line         string
+		translations []string
+		err          bool
+	}{
+		{line: "\n", translations: []string{"привет"}},
+		{line: "3\n", translations: []string{"салют"}},
+		{line: "1, 3", translations: []string{"привет", "салют"}},
+		{line: "2 1", translations: []string{"здравствуй", "привет"}},
+		{line: "-", translations: nil},
+		{line: "хелло, алло", translations: []string{"хелло", "алло"}},
+		{line: "4", err: true},
+		{line: "0", err: true},
+	}
+
+	for _, tt := range tests {
+		translations, err := parseSelection(tt.line, selectionWords)
+		if tt.err {
+			require.ErrorIs(t, err, errTranslationNumber, tt.line)
+			continue
+		}
+		require.NoError(t, err, tt.line)
+		require.Equal(t, tt.translations, translations, tt.line)
+	}
+}
+
+func TestPromptSelectorAsksAgainOnInvalidChoice(t *testing.T) {
+	out := &strings.Builder{}
+	selector := NewPromptSelector(strings.NewReader("7\n2\n"), out)
+
+	translations, err := selector.Select(t.Context(), api.Result{Word: "hello", Translate: selectionWords})
+	require.NoError(t, err)
+	require.Equal(t, []string{"здравствуй"}, translations)
+	require.Contains(t, out.String(), "2. здравствуй (5 votes)")
+	require.Contains(t, out.String(), "try again")
+}
+
+func TestPromptSelectorStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	selector := NewPromptSelector(strings.NewReader(""), &strings.Builder{})
+
+	_, err := selector.Select(ctx, api.Result{Word: "hello", Translate: selectionWords})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestTranslateWithReverseAddsSelectedTranslations(t *testing.T) {
+	client := mock.NewMock_Client(t)
+	for _, word := range []string{"hello", "world"} {
+		client.EXPECT().TranslateWord(testifymock.Anything, word).Return(api.OperationResult{
+			Result: api.Result{Word: word, Translate: selectionWords},
+		}).Once()
+	}
+	client.EXPECT().AddWord(testifymock.Anything, "hello", "салют").Return(api.OperationResult{}).Once()
+
+	app := Lingualeo{
+		Client:   client,
+		Outputer: &outputCollector{},
+		Selector: NewPromptSelector(strings.NewReader("3\n-\n"), &strings.Builder{}),
+		Config:   Config{Add: true, Workers: 1},
+		Words:    []string{"hello", "world"},
+	}
+
+	require.NoError(t, app.TranslateWithReverse(t.Context()))
+}
@@ -0,0 +1,108 @@
+package translator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/trezorg/lingualeo/internal/httpclient"
+)
+
+const (
+	sessionDirName    = "lingualeo"
+	sessionFilePrefix = "session-"
+	sessionFileExt    = ".json"
+	sessionHashLength = 12
+)
+
+// session keeps the cookie jar of the API client between runs.
+type session struct {
+	jar      *httpclient.Jar
+	filename string
+}
+
+// stateDir returns the lingualeo directory under the user's state directory.
+func stateDir() (string, error) {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := lookupUserHome()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+
+	return filepath.Join(dir, sessionDirName), nil
+}
+
+// defaultSessionFile returns a per-account session file under the user's state directory.
+func defaultSessionFile(email string) (string, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return "", err
+	}
+	name := sessionFilePrefix + accountID(email) + sessionFileExt
+
+	return filepath.Join(dir, name), nil
+}
+
+// accountID is a short stable identifier of the account that does not reveal the email.
+func accountID(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(email)))
+	return hex.EncodeToString(sum[:])[:sessionHashLength]
+}
+
+// newSession restores the jar from filename. A missing or broken session file
+// is not an error: the run simply starts without a session.
+func newSession(jar *httpclient.Jar, filename string, email string) (*session, error) {
+	if filename == "" {
+		var err error
+		if filename, err = defaultSessionFile(email); err != nil {
+			return nil, err
+		}
+	}
+	if err := jar.Load(filename); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		slog.Warn("cannot restore session, ignoring it", "file", filename, "error", err)
+	}
+
+	return &session{jar: jar, filename: filename}, nil
+}
+
+func (s *session) restored() bool {
+	return s.jar.Len() > 0
+}
+
+func (s *session) save() error {
+	return s.jar.Save(s.filename)
+}
+
+// Authenticate reuses a session saved by a previous run and authenticates otherwise.
+// A restored session is not checked up front: the API client authenticates again
+// as soon as the server rejects it.
+func (l *Lingualeo) Authenticate(ctx context.Context) error {
+	if l.session != nil && l.session.restored() {
+		slog.DebugContext(ctx, "reusing saved session", "file", l.session.filename)
+		return nil
+	}
+	if err := l.Auth(ctx); err != nil {
+		return err
+	}
+
+	return l.SaveSession()
+}
+
+// SaveSession stores the current session for the next run.
+// It does nothing when session persistence is disabled.
+func (l *Lingualeo) SaveSession() error {
+	if l.session == nil {
+		return nil
+	}
+
+	return l.session.save()
+}
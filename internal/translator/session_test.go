@@ -0,0 +1,95 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trezorg/lingualeo/internal/api/mock"
+	"github.com/trezorg/lingualeo/internal/httpclient"
+)
+
+func newTestSession(t *testing.T, filename string) *session {
+	t.Helper()
+
+	jar, err := httpclient.NewJar()
+	require.NoError(t, err)
+	s, err := newSession(jar, filename, "user@example.com")
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestAuthenticateSavesSessionAfterAuth(t *testing.T) {
+	t.Parallel()
+
+	filename := filepath.Join(t.TempDir(), "session.json")
+	s := newTestSession(t, filename)
+	client := mock.NewMock_Client(t)
+	client.EXPECT().Auth(t.Context()).RunAndReturn(func(_ context.Context) error {
+		s.jar.SetCookies(&url.URL{Scheme: "https", Host: "lingualeo.com"}, []*http.Cookie{{Name: "remember", Value: "token"}})
+		return nil
+	}).Once()
+
+	app := Lingualeo{Client: client, session: s}
+	require.NoError(t, app.Authenticate(t.Context()))
+	require.FileExists(t, filename)
+
+	restored := newTestSession(t, filename)
+	require.True(t, restored.restored())
+}
+
+func TestAuthenticateReusesRestoredSession(t *testing.T) {
+	t.Parallel()
+
+	filename := filepath.Join(t.TempDir(), "session.json")
+	s := newTestSession(t, filename)
+	s.jar.SetCookies(&url.URL{Scheme: "https", Host: "lingualeo.com"}, []*http.Cookie{{Name: "remember", Value: "token"}})
+	require.NoError(t, s.save())
+
+	client := mock.NewMock_Client(t)
+	app := Lingualeo{Client: client, session: newTestSession(t, filename)}
+	require.NoError(t, app.Authenticate(t.Context()))
+	client.AssertNotCalled(t, "Auth")
+}
+
+func TestAuthenticateWithoutSessionAlwaysAuthenticates(t *testing.T) {
+	t.Parallel()
+
+	authErr := errors.New("auth failed")
+	client := mock.NewMock_Client(t)
+	client.EXPECT().Auth(t.Context()).Return(authErr).Once()
+
+	app := Lingualeo{Client: client}
+	require.ErrorIs(t, app.Authenticate(t.Context()), authErr)
+	require.NoError(t, app.SaveSession())
+}
+
+func TestNewSessionIgnoresBrokenFile(t *testing.T) {
+	t.Parallel()
+
+	filename := filepath.Join(t.TempDir(), "session.json")
+	require.NoError(t, os.WriteFile(filename, []byte("not json"), 0o600))
+
+	s := newTestSession(t, filename)
+	require.False(t, s.restored())
+}
+
+func TestDefaultSessionFileUsesStateDir(t *testing.T) {
+	stateDir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", stateDir)
+
+	filename, err := defaultSessionFile("User@Example.com")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(stateDir, "lingualeo"), filepath.Dir(filename))
+
+	other, err := defaultSessionFile("user@example.com")
+	require.NoError(t, err)
+	require.Equal(t, filename, other)
+}
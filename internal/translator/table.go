@@ -0,0 +1,225 @@
+package translator
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/command"
+
+	"golang.org/x/term"
+	"golang.org/x/text/width"
+)
+
+const (
+	defaultTableTranslations = 3
+	defaultPager             = "less -FRX"
+	tableColumnGap           = "  "
+	minTruncatedWidth        = 8
+	ellipsis                 = "…"
+)
+
+var tableHeader = []string{"WORD", "TRANSCRIPTION", "TRANSLATION", "VOTES", "CONTEXT", "STATUS"}
+
+// Indexes of the columns shrunk to fit the terminal.
+const (
+	translationColumn = 2
+	contextColumn     = 4
+)
+
+// TableOutput prints results as aligned columns with a row per translation.
+// Results are kept in memory until Flush or Close, so columns can be aligned:
+// nothing is printed while words are translated, and failures of single words
+// are reported before the table. Use a record format to stream large inputs.
+// In a terminal long cells are truncated to its width and the table is shown
+// with $PAGER when it does not fit the screen.
+type TableOutput struct {
+	w       io.Writer
+	pager   string
+	results []api.Result
+	top     int
+	width   int
+	height  int
+	mu      sync.Mutex
+}
+
+// NewTableOutput creates a table writing into w. It shows at most top translations
+// per word, defaultTableTranslations when top is zero.
+func NewTableOutput(w io.Writer, top int) *TableOutput {
+	o := &TableOutput{w: w, top: top, pager: os.Getenv("PAGER")}
+	if o.top <= 0 {
+		o.top = defaultTableTranslations
+	}
+	if o.pager == "" {
+		o.pager = defaultPager
+	}
+	if f, ok := w.(*os.File); ok && term.IsTerminal(int(f.Fd())) { //nolint:gosec // required by x/term API
+		if cols, rows, err := term.GetSize(int(f.Fd())); err == nil { //nolint:gosec // required by x/term API
+			o.width, o.height = cols, rows
+		}
+	}
+	return o
+}
+
+func (o *TableOutput) Output(ctx context.Context, result api.Result) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.results = append(o.results, result)
+	return nil
+}
+
+// Flush prints the collected results.
+func (o *TableOutput) Flush() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.results) == 0 {
+		return nil
+	}
+	lines := o.render()
+	o.results = nil
+	text := strings.Join(lines, "\n") + "\n"
+	if o.height > 0 && len(lines) >= o.height {
+		err := o.page(text)
+		if err == nil {
+			return nil
+		}
+		slog.Warn("cannot start pager", "pager", o.pager, "error", err)
+	}
+	_, err := io.WriteString(o.w, text)
+	return err
+}
+
+// Close prints the collected results.
+func (o *TableOutput) Close() error {
+	return o.Flush()
+}
+
+func (o *TableOutput) page(text string) error {
+	name, args := command.Parse(o.pager)
+	cmd := exec.Command(name, args...) //nolint:gosec,noctx // the pager is configured by the user
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stdout = o.w
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func (o *TableOutput) rows() [][]string {
+	rows := [][]string{tableHeader}
+	for _, result := range o.results {
+		status := "new"
+		if result.InDictionary() {
+			status = "existing"
+		}
+		translations := result.Translate
+		if len(translations) > o.top {
+			translations = translations[:o.top]
+		}
+		if len(translations) == 0 {
+			translations = []api.Word{{}}
+		}
+		for i, word := range translations {
+			row := []string{"", "", word.Value, strconv.Itoa(word.Votes), oneLine(word.Context), ""}
+			if word.Value == "" {
+				row[3] = ""
+			}
+			if i == 0 {
+				row[0], row[1], row[5] = result.Word, result.Transcription, status
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func (o *TableOutput) render() []string {
+	rows := o.rows()
+	widths := make([]int, len(tableHeader))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], displayWidth(cell))
+		}
+	}
+	o.fit(widths)
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		line := bytes.Buffer{}
+		for i, cell := range row {
+			cell = truncate(cell, widths[i])
+			line.WriteString(cell)
+			if i < len(row)-1 {
+				line.WriteString(strings.Repeat(" ", widths[i]-displayWidth(cell)))
+				line.WriteString(tableColumnGap)
+			}
+		}
+		lines = append(lines, strings.TrimRight(line.String(), " "))
+	}
+	return lines
+}
+
+// fit shrinks the context and translation columns to the terminal width.
+func (o *TableOutput) fit(widths []int) {
+	if o.width <= 0 {
+		return
+	}
+	total := len(tableColumnGap) * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+	for _, column := range []int{contextColumn, translationColumn} {
+		if total <= o.width {
+			return
+		}
+		shrunk := max(widths[column]-(total-o.width), min(widths[column], minTruncatedWidth))
+		total -= widths[column] - shrunk
+		widths[column] = shrunk
+	}
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// displayWidth is the number of terminal cells of s: wide East Asian runes take two.
+func displayWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		n += runeWidth(r)
+	}
+	return n
+}
+
+func runeWidth(r rune) int {
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// truncate cuts s to the width ending it with an ellipsis.
+func truncate(s string, w int) string {
+	if displayWidth(s) <= w {
+		return s
+	}
+	out := strings.Builder{}
+	n := displayWidth(ellipsis)
+	for _, r := range s {
+		if n+runeWidth(r) > w {
+			break
+		}
+		n += runeWidth(r)
+		out.WriteRune(r)
+	}
+	return out.String() + ellipsis
+}
@@ -0,0 +1,82 @@
+package translator
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trezorg/lingualeo/internal/api"
+)
+
+var tableResults = []api.Result{
+	{
+		Word:          "hello",
+		Transcription: "həˈləʊ",
+		Translate: []api.Word{
+			{Value: "привет", Votes: 120, Context: "a friendly\ngreeting used when meeting someone"},
+			{Value: "здравствуйте", Votes: 45},
+			{Value: "алло", Votes: 3},
+		},
+	},
+	{Word: "go", Exists: true, Translate: []api.Word{{Value: "идти", Votes: 7}}},
+	{Word: "qwzx"},
+}
+
+func writeTable(t *testing.T, output *TableOutput) string {
+	t.Helper()
+	for _, result := range tableResults {
+		require.NoError(t, output.Output(t.Context(), result))
+	}
+	require.NoError(t, output.Close())
+	return output.w.(*bytes.Buffer).String()
+}
+
+func TestTableOutputAlignsColumns(t *testing.T) {
+	out := &bytes.Buffer{}
+	output := NewTableOutput(out, 2)
+
+	require.Equal(t, strings.Join([]string{
+		"WORD   TRANSCRIPTION  TRANSLATION   VOTES  CONTEXT                                        STATUS",
+		"hello  həˈləʊ         привет        120    a friendly greeting used when meeting someone  new",
+		"                      здравствуйте  45",
+		"go                    идти          7                                                     existing",
+		"qwzx                                                                                      new",
+		"",
+	}, "\n"), writeTable(t, output))
+
+	// The results are printed once.
+	require.NoError(t, output.Flush())
+	require.Equal(t, 5, strings.Count(out.String(), "\n"))
+}
+
+func TestTableOutputFitsTerminalWidth(t *testing.T) {
+	output := NewTableOutput(&bytes.Buffer{}, 1)
+	output.width = 60
+
+	lines := strings.Split(strings.TrimSpace(writeTable(t, output)), "\n")
+	for _, line := range lines {
+		require.LessOrEqual(t, displayWidth(line), 60, line)
+	}
+	require.Contains(t, lines[1], "a frien…  new")
+}
+
+func TestTableOutputUsesPagerForLongTables(t *testing.T) {
+	output := NewTableOutput(&bytes.Buffer{}, 0)
+	output.height = 3
+	output.pager = "sed s/^/paged:/"
+
+	lines := strings.Split(strings.TrimSpace(writeTable(t, output)), "\n")
+	require.Len(t, lines, 6)
+	for _, line := range lines {
+		require.True(t, strings.HasPrefix(line, "paged:"), line)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	require.Equal(t, "hello", truncate("hello", 5))
+	require.Equal(t, "hel…", truncate("hello", 4))
+	require.Equal(t, "日…", truncate("日本語", 4))
+	require.Equal(t, 6, displayWidth("日本語"))
+}
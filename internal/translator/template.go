@@ -0,0 +1,119 @@
+package translator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/trezorg/lingualeo/internal/api"
+	"github.com/trezorg/lingualeo/internal/messages"
+)
+
+var (
+	errTemplateAndFile   = errors.New("template and template file cannot be used together")
+	errTemplateFormat    = errors.New("template output cannot be used with other output formats")
+	errTemplateArguments = errors.New("inDictionary expects a result or a translation")
+)
+
+// templateFuncs are the helpers available in output templates.
+var templateFuncs = template.FuncMap{
+	// join joins strings: {{ .Translate | values | join ", " }}
+	"join": func(sep string, items []string) string {
+		return strings.Join(items, sep)
+	},
+	// values returns the translation values
+	"values": func(words []api.Word) []string {
+		values := make([]string, 0, len(words))
+		for _, word := range words {
+			values = append(values, word.Value)
+		}
+		return values
+	},
+	// top returns at most n most voted translations: {{ .Translate | top 3 }}
+	"top": func(n int, words []api.Word) []api.Word {
+		if n >= 0 && len(words) > n {
+			return words[:n]
+		}
+		return words
+	},
+	// inDictionary reports whether a result or a translation is in the dictionary
+	"inDictionary": func(value any) (bool, error) {
+		switch v := value.(type) {
+		case *api.Result:
+			return v.InDictionary(), nil
+		case api.Result:
+			return v.InDictionary(), nil
+		case api.Word:
+			return bool(v.Exists), nil
+		default:
+			return false, fmt.Errorf("%w: %T", errTemplateArguments, value)
+		}
+	},
+	// color colors the text: {{ color "green" .Word }}
+	"color": func(name string, text string) (string, error) {
+		c, err := messages.ParseColor(name)
+		if err != nil {
+			return "", err
+		}
+		return messages.Colorize(c, text), nil
+	},
+	// forms joins word forms, e.g. "went (past), gone (past participle)"
+	"forms": formatWordForms,
+}
+
+// TemplateOutput prints results with a text/template. The template gets
+// the *api.Result, see templateFuncs for the helpers.
+// A newline is added when the output of a result does not end with one.
+type TemplateOutput struct {
+	template *template.Template
+	w        io.Writer
+	mu       sync.Mutex
+}
+
+// NewTemplateOutput parses the template writing results into w.
+func NewTemplateOutput(text string, w io.Writer) (*TemplateOutput, error) {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse output template: %w", err)
+	}
+	return &TemplateOutput{template: tmpl, w: w}, nil
+}
+
+func (c *Config) usesTemplate() bool {
+	return c.Template != "" || c.TemplateFile != ""
+}
+
+// templateText returns the template given in the config or in the template file.
+func (c *Config) templateText() (string, error) {
+	if c.TemplateFile == "" {
+		return c.Template, nil
+	}
+	data, err := os.ReadFile(c.TemplateFile)
+	if err != nil {
+		return "", fmt.Errorf("read output template: %w", err)
+	}
+	return string(data), nil
+}
+
+func (o *TemplateOutput) Output(ctx context.Context, result api.Result) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	buf := bytes.Buffer{}
+	if err := o.template.Execute(&buf, &result); err != nil {
+		return fmt.Errorf("execute output template: %w", err)
+	}
+	if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	_, err := o.w.Write(buf.Bytes())
+	return err
+}
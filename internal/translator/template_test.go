@@ -0,0 +1,85 @@
+package translator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trezorg/lingualeo/internal/api"
+)
+
+var templateResult = api.Result{
+	Word:          "go",
+	Transcription: "ɡəʊ",
+	Exists:        true,
+	WordForms:     []api.WordForm{{Word: "went", Type: "past"}},
+	Translate:     []api.Word{{Value: "идти", Votes: 10, Exists: true}, {Value: "ехать", Votes: 5}, {Value: "ход", Votes: 1}},
+}
+
+func TestTemplateOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{
+			name:     "one-liner",
+			template: `{{ .Word }} [{{ .Transcription }}]: {{ .Translate | top 2 | values | join ", " }}`,
+			want:     "go [ɡəʊ]: идти, ехать\n",
+		},
+		{
+			name:     "verbose",
+			template: "{{ if inDictionary . }}existing{{ end }} {{ forms .WordForms }}\n{{ range .Translate }}{{ .Value }} {{ .Votes }}{{ if inDictionary . }} *{{ end }}\n{{ end }}",
+			want:     "existing went (past)\nидти 10 *\nехать 5\nход 1\n",
+		},
+		{
+			name:     "method",
+			template: `{{ .InDictionary }} {{ color "green" .Word }}`,
+			want:     "true go\n",
+		},
+		{
+			name:     "empty",
+			template: `{{ if false }}{{ .Word }}{{ end }}`,
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			output, err := NewTemplateOutput(tt.template, out)
+			require.NoError(t, err)
+			require.NoError(t, output.Output(t.Context(), templateResult))
+			require.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestTemplateOutputErrors(t *testing.T) {
+	_, err := NewTemplateOutput(`{{ .Word `, &bytes.Buffer{})
+	require.Error(t, err)
+
+	output, err := NewTemplateOutput(`{{ color "purple" .Word }}`, &bytes.Buffer{})
+	require.NoError(t, err)
+	require.Error(t, output.Output(t.Context(), templateResult))
+
+	output, err = NewTemplateOutput(`{{ inDictionary .Word }}`, &bytes.Buffer{})
+	require.NoError(t, err)
+	require.ErrorIs(t, output.Output(t.Context(), templateResult), errTemplateArguments)
+}
+
+func TestConfigTemplateText(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "output.tmpl")
+	require.NoError(t, os.WriteFile(filename, []byte("{{ .Word }}"), 0o600))
+
+	text, err := (&Config{TemplateFile: filename}).templateText()
+	require.NoError(t, err)
+	require.Equal(t, "{{ .Word }}", text)
+
+	text, err = (&Config{Template: "{{ .Pos }}"}).templateText()
+	require.NoError(t, err)
+	require.Equal(t, "{{ .Pos }}", text)
+}
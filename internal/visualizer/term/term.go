@@ -25,7 +25,24 @@ const (
 	Unknown GraphicMode = "unknown"
 )
 
-var httpClient = httpclient.New(httpclient.Config{})
+// defaultHTTPClient is used when no client is given with WithHTTPClient.
+var defaultHTTPClient = http.DefaultClient
+
+type config struct {
+	client *http.Client
+}
+
+// Option is a functional option for Visualizer configuration.
+type Option func(*config)
+
+// WithHTTPClient sets the HTTP client used to download pictures.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) {
+		if client != nil {
+			c.client = client
+		}
+	}
+}
 
 var (
 	errBadStatus       = errors.New("bad status")
@@ -113,14 +130,14 @@ func showImage(ctx context.Context, w io.Writer, r io.Reader) error {
 	return err
 }
 
-func open(ctx context.Context, u *url.URL) error {
+func open(ctx context.Context, client *http.Client, u *url.URL) error {
 	ctx, cancel := context.WithTimeoutCause(ctx, httpclient.DefaultTimeout, errReadURLTimeout)
 	defer cancel()
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return fmt.Errorf("%w: %s, %w", errCannotReadURL, u.String(), err)
 	}
-	resp, err := httpClient.Do(req) //nolint:gosec // URL is parsed and validated before visualizing
+	resp, err := client.Do(req) //nolint:gosec // URL is parsed and validated before visualizing
 	if err != nil {
 		return fmt.Errorf("%w: %s, %w", errCannotReadURL, u.String(), err)
 	}
@@ -144,6 +161,12 @@ func (v Visualizer) Show(ctx context.Context, u *url.URL) error {
 	return v(ctx, u)
 }
 
-func New() Visualizer {
-	return Visualizer(open)
+func New(opts ...Option) Visualizer {
+	cfg := config{client: defaultHTTPClient}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return func(ctx context.Context, u *url.URL) error {
+		return open(ctx, cfg.client, u)
+	}
 }
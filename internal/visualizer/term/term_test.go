@@ -78,7 +78,7 @@ func TestOpenWithBadURL(t *testing.T) {
 	require.NoError(t, err)
 
 	ctx := t.Context()
-	err = open(ctx, testURL)
+	err = open(ctx, defaultHTTPClient, testURL)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "bad status")
 }
@@ -91,7 +91,7 @@ func TestOpenWithInvalidURL(t *testing.T) {
 	ctx, cancel := context.WithTimeoutCause(t.Context(), 100, context.DeadlineExceeded)
 	defer cancel()
 
-	err = open(ctx, testURL)
+	err = open(ctx, defaultHTTPClient, testURL)
 	require.Error(t, err)
 }
 